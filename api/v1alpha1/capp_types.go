@@ -22,6 +22,7 @@ import (
 	dnsrecordv1alpha1 "github.com/dana-team/provider-dns-v2/apis/namespaced/record/v1alpha1"
 	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kapis "knative.dev/pkg/apis"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -47,10 +48,34 @@ const (
 	CappReadyReasonCertificateNotReady   = "CertificateNotReady"
 	CappReadyReasonVolumesNotReady       = "VolumesNotReady"
 	CappReadyReasonEventingNotReady      = "EventingNotReady"
+	CappReadyReasonSecretsNotReady       = "SecretsNotReady"
 
 	// CappReadyReasonResourceSyncFailed indicates that one or more child resources
 	// could not be created or updated during reconciliation (e.g. a webhook validation error).
 	CappReadyReasonResourceSyncFailed = "ResourceSyncFailed"
+
+	// CappConditionPolicyCompliant indicates whether the Capp complies with the limits of its
+	// effective CappConfig. It is informational and does not affect the Ready condition.
+	CappConditionPolicyCompliant = "PolicyCompliant"
+
+	// Reasons for the PolicyCompliant condition.
+	CappPolicyReasonCompliant = "Compliant"
+	CappPolicyReasonViolated  = "PolicyViolated"
+
+	// CappConditionDrifted indicates whether child resources have out-of-band changes that were
+	// left in place because drift correction is paused for the Capp.
+	CappConditionDrifted = "Drifted"
+
+	// Reasons for the Drifted condition.
+	CappDriftReasonDetected = "DriftDetected"
+	CappDriftReasonNone     = "NoDrift"
+
+	// CappConditionPaused indicates that reconciliation of the Capp child resources is paused
+	// through spec.paused.
+	CappConditionPaused = "Paused"
+
+	// CappPausedReasonPaused is the reason of the Paused condition.
+	CappPausedReasonPaused = "PausedBySpec"
 )
 
 // CappSpec defines the desired state of Capp.
@@ -62,6 +87,17 @@ type CappSpec struct {
 	// +kubebuilder:validation:Enum=enabled;disabled
 	State string `json:"state,omitempty"`
 
+	// Paused stops the operator from creating, updating and deleting the child resources of the Capp
+	// and from recording new CappRevisions, while the status keeps being updated.
+	// It is meant for manual mitigation on child resources, which would otherwise be reverted.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// RevisionHistoryLimit overrides, for this Capp, how many CappRevisions the CappConfig retains.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+
 	// ScaleSpec holds the Capp scaling configuration.
 	ScaleSpec ScaleSpec `json:"scaleSpec"`
 
@@ -81,6 +117,14 @@ type CappSpec struct {
 	// EventSourcesSpec defines the event sources for the Capp.
 	// +optional
 	EventSourcesSpec EventSourcesSpec `json:"eventSourcesSpec,omitempty"`
+
+	// SecretsSpec defines secrets synced from external secret stores into the environment of the Capp.
+	// +optional
+	SecretsSpec SecretsSpec `json:"secretsSpec,omitempty"`
+
+	// NetworkSpec defines which sources may call the Capp and which destinations it may reach.
+	// +optional
+	NetworkSpec NetworkSpec `json:"networkSpec,omitempty"`
 }
 
 // ScaleSpec defines the scale specification for the Capp.
@@ -105,6 +149,23 @@ type ScaleSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	ScaleDelaySeconds *int32 `json:"scaleDelaySeconds,omitempty"`
+
+	// InitialScale is the number of replicas the Capp starts with when a new revision is created.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialScale *int32 `json:"initialScale,omitempty"`
+
+	// ScaleToZeroRetentionSeconds is the minimum time in seconds the last replica is kept
+	// after the Autoscaler decides to scale the Capp to zero.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ScaleToZeroRetentionSeconds *int32 `json:"scaleToZeroRetentionSeconds,omitempty"`
+
+	// AllowScaleToZero defines whether the Capp may scale down to zero replicas.
+	// When false, at least one replica is always kept warm. Defaults to true unless
+	// the Capp namespace is listed in the CappConfig warm namespaces.
+	// +optional
+	AllowScaleToZero *bool `json:"allowScaleToZero,omitempty"`
 }
 
 // EventSourcesSpec defines all event sources for a Capp.
@@ -177,10 +238,144 @@ type SourceConfiguration struct {
 	KafkaSourceConfiguration *KafkaSourceConfiguration `json:"kafkaSourceConfiguration,omitempty"`
 }
 
+// SecretsSpec defines the secrets of the Capp synced from external secret stores.
+type SecretsSpec struct {
+	// Secrets is a list of secrets the External Secrets Operator syncs from external secret stores.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Secrets []ExternalSecret `json:"secrets,omitempty"`
+}
+
+// ExternalSecret defines a secret synced from an external secret store into a Secret named
+// <capp-name>-<name>, whose keys are set as environment variables of the containers of the Capp.
+type ExternalSecret struct {
+	// Name is the name of the secret.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// StoreRef references the secret store the secret is read from.
+	StoreRef SecretStoreRef `json:"storeRef"`
+
+	// RefreshInterval is how often the secret is read again from the store. A change to its content
+	// rolls out a new revision of the Capp. Defaults to 1h.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// Env maps keys of the secret in the store to environment variables of the containers.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Env []ExternalSecretEnvVar `json:"env"`
+}
+
+// SecretStoreRef references a SecretStore or a ClusterSecretStore of the External Secrets Operator.
+type SecretStoreRef struct {
+	// Name is the name of the secret store.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Kind is the kind of the secret store: a SecretStore in the namespace of the Capp or a
+	// ClusterSecretStore. Defaults to SecretStore.
+	// +optional
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	Kind string `json:"kind,omitempty"`
+}
+
+// ExternalSecretEnvVar maps a key of a secret in an external secret store to an environment variable.
+type ExternalSecretEnvVar struct {
+	// Name is the name of the environment variable.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z][-._a-zA-Z0-9]*$`
+	Name string `json:"name"`
+
+	// Key is the key of the secret in the store.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Property is the property of the secret in the store to read, for stores holding structured
+	// secrets.
+	// +optional
+	Property string `json:"property,omitempty"`
+
+	// Containers are the names of the containers the variable is set in. The variable is set in
+	// every container of the Capp if it is empty.
+	// +optional
+	// +listType=set
+	Containers []string `json:"containers,omitempty"`
+}
+
+// NetworkSpec defines the network isolation of the Capp, enforced by a NetworkPolicy. The Knative
+// data plane is always allowed to reach the Capp, so that routing and scale-from-zero keep working.
+type NetworkSpec struct {
+	// Ingress is a list of sources allowed to call the Capp. Ingress is not restricted if it is empty,
+	// unless default deny is enabled in the CappConfig.
+	// +optional
+	Ingress []NetworkIngressPeer `json:"ingress,omitempty"`
+
+	// Egress is a list of destinations the Capp is allowed to reach, in addition to DNS. Egress is not
+	// restricted if it is empty, unless default deny is enabled in the CappConfig.
+	// +optional
+	Egress []NetworkEgressPeer `json:"egress,omitempty"`
+}
+
+// NetworkIngressPeer is a source allowed to call a Capp: every pod of a namespace, or a Capp.
+// +kubebuilder:validation:XValidation:rule="has(self.__namespace__) || has(self.cappName)",message="either namespace or cappName must be set"
+type NetworkIngressPeer struct {
+	// Namespace is the namespace of the source. It defaults to the namespace of the Capp if CappName
+	// is set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CappName is the name of the Capp allowed to call.
+	// +optional
+	CappName string `json:"cappName,omitempty"`
+}
+
+// NetworkEgressPeer is a destination a Capp is allowed to reach: a CIDR, or a Capp.
+// +kubebuilder:validation:XValidation:rule="has(self.cidr) != has(self.cappName)",message="exactly one of cidr or cappName must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.__namespace__) || has(self.cappName)",message="namespace can only be set with cappName"
+type NetworkEgressPeer struct {
+	// CIDR is a range of IP addresses, such as 10.0.0.0/16.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+
+	// CappName is the name of the Capp allowed to be reached.
+	// +optional
+	CappName string `json:"cappName,omitempty"`
+
+	// Namespace is the namespace of the Capp allowed to be reached. It defaults to the namespace of
+	// the Capp.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // VolumesSpec defines the volumes specification for the Capp.
 type VolumesSpec struct {
 	// NFSVolumes is a list of NFS volumes to be mounted.
 	NFSVolumes []NFSVolume `json:"nfsVolumes,omitempty"`
+
+	// PVCVolumes is a list of PersistentVolumeClaim volumes to be mounted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PVCVolumes []PVCVolume `json:"pvcVolumes,omitempty"`
+
+	// ConfigMapVolumes is a list of ConfigMap volumes to be mounted. A change to the content of a
+	// mounted ConfigMap rolls out a new revision of the Capp.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ConfigMapVolumes []ConfigMapVolume `json:"configMapVolumes,omitempty"`
+
+	// SecretVolumes is a list of Secret volumes to be mounted. A change to the content of a
+	// mounted Secret rolls out a new revision of the Capp.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SecretVolumes []SecretVolume `json:"secretVolumes,omitempty"`
 }
 
 // NFSVolume defines the NFS volume specification for the Capp.
@@ -202,6 +397,135 @@ type NFSVolume struct {
 
 	// Capacity is the capacity of the volume.
 	Capacity corev1.ResourceList `json:"capacity"`
+
+	// ReclaimPolicy decides what happens to the NfsPvc of the volume when the volume is removed from
+	// the Capp or the Capp is deleted. Defaults to Delete.
+	// +optional
+	ReclaimPolicy VolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// AccessMode is the access mode of the NfsPvc of the volume. A ReadOnlyMany volume is mounted
+	// read-only in every container. Defaults to ReadWriteMany.
+	// +optional
+	// +kubebuilder:validation:Enum=ReadWriteMany;ReadOnlyMany
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+
+	// Mounts are mounts of the volume the operator adds to the containers of the Capp, in addition
+	// to the volumeMounts declared by the containers themselves.
+	// +optional
+	// +listType=map
+	// +listMapKey=mountPath
+	Mounts []NFSVolumeMount `json:"mounts,omitempty"`
+}
+
+// NFSVolumeMount defines a mount of an NFS volume the operator adds to containers of the Capp.
+type NFSVolumeMount struct {
+	// MountPath is the path within the containers at which the volume is mounted.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('/')",message="mountPath must start with '/'"
+	MountPath string `json:"mountPath"`
+
+	// SubPath is the path within the volume to mount instead of its root.
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+
+	// ReadOnly mounts the volume read-only.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Containers are the names of the containers the volume is mounted in. The volume is mounted in
+	// every container of the Capp if it is empty.
+	// +optional
+	// +listType=set
+	Containers []string `json:"containers,omitempty"`
+}
+
+// VolumeReclaimPolicy decides what happens to a volume the operator created for a Capp once the Capp
+// no longer mounts it.
+// +kubebuilder:validation:Enum=Delete;Retain
+type VolumeReclaimPolicy string
+
+const (
+	// VolumeReclaimPolicyDelete deletes the volume.
+	VolumeReclaimPolicyDelete VolumeReclaimPolicy = "Delete"
+	// VolumeReclaimPolicyRetain orphans the volume so that it outlives the Capp and can be adopted by
+	// a Capp declaring a volume of the same name.
+	VolumeReclaimPolicyRetain VolumeReclaimPolicy = "Retain"
+)
+
+// PVCVolume defines a PersistentVolumeClaim volume for the Capp. It either references an existing
+// claim with claimName, or has the operator create a claim, owned by the Capp, of the given size.
+// +kubebuilder:validation:XValidation:rule="has(self.claimName) != has(self.size)",message="exactly one of claimName or size must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.size) || (!has(self.storageClassName) && !has(self.accessMode) && !has(self.reclaimPolicy))",message="storageClassName, accessMode and reclaimPolicy may only be set together with size"
+type PVCVolume struct {
+	// Name is the name of the volume.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ClaimName is the name of an existing PersistentVolumeClaim in the namespace of the Capp.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName,omitempty"`
+
+	// Size is the storage requested by the claim the operator creates.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the claim the operator creates.
+	// The default storage class of the cluster is used if it is not set.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessMode is the access mode of the claim the operator creates. Defaults to ReadWriteOnce.
+	// +optional
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadOnlyMany;ReadWriteMany;ReadWriteOncePod
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+
+	// ReadOnly mounts the claim read-only.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// ReclaimPolicy decides what happens to the claim the operator creates when the volume is removed
+	// from the Capp or the Capp is deleted. Defaults to Delete.
+	// +optional
+	ReclaimPolicy VolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
+
+// ConfigMapVolume defines a volume projecting a ConfigMap in the namespace of the Capp.
+type ConfigMapVolume struct {
+	// Name is the name of the volume.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ConfigMapName is the name of the ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	ConfigMapName string `json:"configMapName"`
+
+	// Items selects the keys of the ConfigMap to project and the paths to project them to.
+	// All keys are projected if it is empty.
+	// +optional
+	Items []corev1.KeyToPath `json:"items,omitempty"`
+}
+
+// SecretVolume defines a volume projecting a Secret in the namespace of the Capp.
+type SecretVolume struct {
+	// Name is the name of the volume.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// SecretName is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// Items selects the keys of the Secret to project and the paths to project them to.
+	// All keys are projected if it is empty.
+	// +optional
+	Items []corev1.KeyToPath `json:"items,omitempty"`
 }
 
 // RouteSpec defines the route specification for the Capp.
@@ -224,8 +548,49 @@ type RouteSpec struct {
 	// that the request instance is allowed to respond to a request.
 	// +optional
 	RouteTimeoutSeconds *int64 `json:"routeTimeoutSeconds,omitempty"`
+
+	// Visibility defines where the Capp is reachable from: outside the cluster through the default
+	// domain (external), or only inside the cluster (cluster-local). A cluster-local Capp cannot have
+	// a hostname. Defaults to external.
+	// +kubebuilder:validation:Enum=external;cluster-local
+	// +optional
+	Visibility RouteVisibility `json:"visibility,omitempty"`
+
+	// Auth requires an OIDC login in front of the route of the Capp. Only an external Capp can
+	// require it.
+	// +optional
+	Auth *RouteAuth `json:"auth,omitempty"`
+}
+
+// RouteAuth defines the OIDC login required in front of the route of a Capp.
+type RouteAuth struct {
+	// IssuerURL is the URL of the OIDC issuer, such as https://login.example.com/realms/apps.
+	// +kubebuilder:validation:Pattern=`^https://`
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientSecret is the name of a Secret in the namespace of the Capp holding the client-id and
+	// client-secret of the OIDC client, and the cookie-secret used to sign session cookies.
+	// +kubebuilder:validation:MinLength=1
+	ClientSecret string `json:"clientSecret"`
+
+	// AllowedGroups is a list of the groups allowed to log in. Any authenticated user is allowed
+	// if it is empty.
+	// +optional
+	// +listType=set
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
 }
 
+// RouteVisibility defines where a Capp is reachable from.
+type RouteVisibility string
+
+const (
+	// RouteVisibilityExternal means the Capp is reachable from outside the cluster.
+	RouteVisibilityExternal RouteVisibility = "external"
+
+	// RouteVisibilityClusterLocal means the Capp is only reachable from inside the cluster.
+	RouteVisibilityClusterLocal RouteVisibility = "cluster-local"
+)
+
 type LogType string
 
 const (
@@ -284,6 +649,18 @@ type StateStatus struct {
 	LastChange metav1.Time `json:"lastChange,omitempty"`
 }
 
+// ExternalSecretStatus shows the state of a secret synced from an external secret store.
+type ExternalSecretStatus struct {
+	// Name is the name of the secret.
+	Name string `json:"name"`
+
+	// SecretName is the name of the Secret the secret is synced into.
+	SecretName string `json:"secretName"`
+
+	// Synced is true once the Secret exists.
+	Synced bool `json:"synced"`
+}
+
 // EventingStatus shows the observed state of all event sources linked to the Capp.
 type EventingStatus struct {
 	// EventSources lists the status of each owned event source resource.
@@ -329,6 +706,10 @@ type RouteStatus struct {
 	// CertificateObjectStatus is the status of the underlying Certificate object
 	// +optional
 	CertificateObjectStatus cmapi.CertificateStatus `json:"certificateObjectStatus,omitempty"`
+
+	// InternalURL is the URL the Capp is reachable at from inside the cluster.
+	// +optional
+	InternalURL string `json:"internalURL,omitempty"`
 }
 
 type DNSRecordObjectStatus struct {
@@ -341,6 +722,23 @@ type DNSRecordObjectStatus struct {
 type VolumesStatus struct {
 	// NFSVolumeStatus is the status of the underlying NFSVolume objects.
 	NFSVolumesStatus []NFSVolumeStatus `json:"nfsVolumesStatus,omitempty"`
+
+	// PVCVolumesStatus is the status of the claims of the PVC volumes.
+	// +optional
+	PVCVolumesStatus []PVCVolumeStatus `json:"pvcVolumesStatus,omitempty"`
+}
+
+// PVCVolumeStatus shows the state of the claim of a PVC volume.
+type PVCVolumeStatus struct {
+	// VolumeName is the name of the volume.
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// ClaimName is the name of the PersistentVolumeClaim of the volume.
+	ClaimName string `json:"claimName,omitempty"`
+
+	// Phase is the phase of the claim, empty if the claim does not exist.
+	// +optional
+	Phase corev1.PersistentVolumeClaimPhase `json:"phase,omitempty"`
 }
 
 type NFSVolumeStatus struct {
@@ -349,8 +747,21 @@ type NFSVolumeStatus struct {
 
 	// NFSPVCStatus is the status of the underlying NfsPvc object.
 	NFSPVCStatus nfspvcv1alpha1.NfsPvcStatus `json:"nfsPvcStatus,omitempty"`
+
+	// Phase is Resizing while the persistent volume of the NfsPvc is smaller than the capacity of the volume.
+	// +optional
+	Phase NFSVolumePhase `json:"phase,omitempty"`
 }
 
+// NFSVolumePhase is the phase of an NFS volume of a Capp.
+type NFSVolumePhase string
+
+const (
+	// NFSVolumePhaseResizing means the capacity of the volume was increased and its persistent volume
+	// does not reflect the new capacity yet.
+	NFSVolumePhaseResizing NFSVolumePhase = "Resizing"
+)
+
 // CappStatus defines the observed state of Capp.
 type CappStatus struct {
 	// KnativeObjectStatus represents the Status stanza of the Service resource.
@@ -381,13 +792,33 @@ type CappStatus struct {
 	// +optional
 	EventingStatus EventingStatus `json:"eventingStatus,omitempty"`
 
+	// SecretsStatus shows the state of the secrets synced from external secret stores.
+	// +optional
+	SecretsStatus []ExternalSecretStatus `json:"secretsStatus,omitempty"`
+
+	// ConfigStatus shows the CappConfig in effect for the Capp.
+	// +optional
+	ConfigStatus ConfigStatus `json:"configStatus,omitempty"`
+
 	// Conditions contain details about the current state of the Capp.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ConfigStatus shows the effective CappConfig of a Capp, after namespace overrides are merged over the global config.
+type ConfigStatus struct {
+	// AppliedOverrides is the list of CappConfigOverrides merged into the effective config, in order of application.
+	// +optional
+	AppliedOverrides []string `json:"appliedOverrides,omitempty"`
+
+	// EffectiveConfig is the CappConfig spec used when reconciling and admitting the Capp.
+	// +optional
+	EffectiveConfig *CappConfigSpec `json:"effectiveConfig,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:printcolumn:name="Custom URL",type="string",JSONPath=".spec.routeSpec.hostname",description="shorten url"
+// +kubebuilder:printcolumn:name="Internal URL",type="string",JSONPath=".status.routeStatus.internalURL",priority=1,description="url inside the cluster"
 // +kubebuilder:printcolumn:name="AutoScale Type",type="string",JSONPath=".spec.scaleSpec.metric",description="autoscale metric"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="whether the Capp and its child resources are ready"
 // +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="reason for the current Ready status"
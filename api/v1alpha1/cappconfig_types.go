@@ -39,10 +39,100 @@ type CappConfigSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	RevisionHistoryLimit int `json:"revisionHistoryLimit,omitempty"`
 
+	// RevisionRetentionPeriod, if set, is how long CappRevisions are retained. Older revisions are
+	// deleted even when fewer than RevisionHistoryLimit exist, except for the latest and protected ones.
+	// +optional
+	RevisionRetentionPeriod *metav1.Duration `json:"revisionRetentionPeriod,omitempty"`
+
 	// MaxKafkaConsumers is the maximum allowed KafkaSource consumers per kafka source entry.
 	// +kubebuilder:default:=5
 	// +kubebuilder:validation:Minimum=1
 	MaxKafkaConsumers int32 `json:"maxKafkaConsumers,omitempty"`
+
+	// ImagePolicy restricts the container images Capps are allowed to use.
+	// +optional
+	ImagePolicy ImagePolicy `json:"imagePolicy,omitempty"`
+
+	// NetworkConfig configures the NetworkPolicies of Capps.
+	// +optional
+	NetworkConfig NetworkConfig `json:"networkConfig,omitempty"`
+
+	// AuthConfig configures how the OIDC login of Capps is enforced.
+	// +optional
+	AuthConfig AuthConfig `json:"authConfig,omitempty"`
+
+	// SecurityConfig sets the security context defaults of the containers of Capps.
+	// +optional
+	SecurityConfig SecurityConfig `json:"securityConfig,omitempty"`
+}
+
+// SecurityConfig defines the security context defaults injected into every container of Capps and
+// whether Capps may request privilege escalation.
+type SecurityConfig struct {
+	// RunAsNonRoot defaults runAsNonRoot to true.
+	// +optional
+	RunAsNonRoot bool `json:"runAsNonRoot,omitempty"`
+
+	// DropAllCapabilities defaults the dropped capabilities to ALL.
+	// +optional
+	DropAllCapabilities bool `json:"dropAllCapabilities,omitempty"`
+
+	// ReadOnlyRootFilesystem defaults readOnlyRootFilesystem to true.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// SeccompRuntimeDefault defaults the seccomp profile to RuntimeDefault.
+	// +optional
+	SeccompRuntimeDefault bool `json:"seccompRuntimeDefault,omitempty"`
+
+	// ForbidPrivilegeEscalation rejects Capps with containers that are privileged or allow privilege escalation.
+	// +optional
+	ForbidPrivilegeEscalation bool `json:"forbidPrivilegeEscalation,omitempty"`
+
+	// ExemptNamespaces is a list of namespaces whose Capps get none of the defaults and may request
+	// privilege escalation.
+	// +optional
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// AuthConfig configures the oauth2-proxy sidecar enforcing the OIDC login of Capps.
+type AuthConfig struct {
+	// ProxyImage is the image of the oauth2-proxy sidecar. Defaults to quay.io/oauth2-proxy/oauth2-proxy:v7.6.0.
+	// +optional
+	ProxyImage string `json:"proxyImage,omitempty"`
+}
+
+// NetworkConfig configures the NetworkPolicies the operator generates for Capps.
+type NetworkConfig struct {
+	// DefaultDeny isolates every Capp, denying the ingress and egress its networkSpec does not allow,
+	// even if it has none.
+	// +optional
+	DefaultDeny bool `json:"defaultDeny,omitempty"`
+
+	// SystemNamespaces is a list of the namespaces of the Knative data plane (activator, autoscaler
+	// and ingress gateways), which are always allowed to reach Capps and through which Capps reach
+	// each other. Defaults to knative-serving and kourier-system.
+	// +optional
+	SystemNamespaces []string `json:"systemNamespaces,omitempty"`
+}
+
+// ImagePolicy defines which container images Capps are allowed to use.
+type ImagePolicy struct {
+	// AllowedRegistries is a list of image prefixes (e.g. "ghcr.io/dana-team/") that Capp images must start with.
+	// A prefix only matches whole path segments, so "registry.example.com" does not match
+	// "registry.example.com.other.io/app". Images without a registry are matched in their fully
+	// qualified form (e.g. "docker.io/library/nginx").
+	// An empty list allows images from any registry.
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// DigestRequiredNamespaces is a list of namespaces whose Capps must pin every image by digest.
+	// +optional
+	DigestRequiredNamespaces []string `json:"digestRequiredNamespaces,omitempty"`
+
+	// ForbidLatestTag forbids images tagged "latest", including images without any tag or digest.
+	// +optional
+	ForbidLatestTag bool `json:"forbidLatestTag,omitempty"`
 }
 
 // IssuerRef identifies a cert-manager issuer by name, kind, and API group.
@@ -99,12 +189,48 @@ type AutoscaleConfig struct {
 	// +kubebuilder:default:=3600
 	// +kubebuilder:validation:Minimum=0
 	MaxScaleDelay int `json:"maxScaleDelay"`
+	// MaxScaleToZeroRetention is the maximum allowed value in seconds for scaleToZeroRetentionSeconds.
+	// +kubebuilder:default:=3600
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleToZeroRetention int `json:"maxScaleToZeroRetention,omitempty"`
+	// WarmNamespaces is a list of namespaces whose Capps must always keep at least one warm replica.
+	// Capps in these namespaces are not allowed to scale to zero.
+	// +optional
+	WarmNamespaces []string `json:"warmNamespaces,omitempty"`
 }
 
+const (
+	// CappConfigConditionValid reports whether the CappConfig passed self-validation.
+	CappConfigConditionValid = "Valid"
+	// CappConfigConditionCappsCompliant reports whether all Capps comply with the CappConfig limits.
+	CappConfigConditionCappsCompliant = "CappsCompliant"
+
+	CappConfigReasonValid              = "Valid"
+	CappConfigReasonInvalid            = "InvalidConfig"
+	CappConfigReasonAllCappsCompliant  = "AllCappsCompliant"
+	CappConfigReasonCappsViolateLimits = "CappsViolateLimits"
+)
+
 // CappConfigStatus defines the observed state of CappConfig
-type CappConfigStatus struct{}
+type CappConfigStatus struct {
+	// ObservedGeneration is the most recent generation of the CappConfig that was validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ViolatingCapps is the number of Capps that currently violate the limits of their effective config.
+	// +optional
+	ViolatingCapps int32 `json:"violatingCapps,omitempty"`
+
+	// Conditions contain details about the validity of the CappConfig and the compliance of Capps with it.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type=='Valid')].status",description="whether the CappConfig passed validation"
+// +kubebuilder:printcolumn:name="Violating Capps",type="integer",JSONPath=".status.violatingCapps",description="number of Capps violating the config limits"
 
 // CappConfig is the Schema for the cappconfigs API
 type CappConfig struct {
@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CappConfigOverrideSpec defines the desired state of CappConfigOverride.
+// Every field except NamespaceSelector is optional; unset fields inherit the value of the global CappConfig.
+type CappConfigOverrideSpec struct {
+	// NamespaceSelector selects the namespaces whose Capps the override applies to.
+	// +kubebuilder:validation:Required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// AutoscaleConfig overrides individual fields of the global autoscale config.
+	// +optional
+	AutoscaleConfig *AutoscaleConfigOverride `json:"autoscaleConfig,omitempty"`
+
+	// DefaultResources overrides the default resources assigned to Capps.
+	// Requests and limits are merged per resource name over the global defaults.
+	// +optional
+	DefaultResources *corev1.ResourceRequirements `json:"defaultResources,omitempty"`
+
+	// AllowedHostnamePatterns replaces the global list of allowed hostname patterns.
+	// +optional
+	AllowedHostnamePatterns []HostnamePattern `json:"allowedHostnamePatterns,omitempty"`
+
+	// RevisionHistoryLimit overrides how many CappRevisions will be retained.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+
+	// RevisionRetentionPeriod overrides how long CappRevisions are retained.
+	// +optional
+	RevisionRetentionPeriod *metav1.Duration `json:"revisionRetentionPeriod,omitempty"`
+
+	// MaxKafkaConsumers overrides the maximum allowed KafkaSource consumers per kafka source entry.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxKafkaConsumers *int32 `json:"maxKafkaConsumers,omitempty"`
+
+	// ImagePolicy replaces the global image policy.
+	// +optional
+	ImagePolicy *ImagePolicy `json:"imagePolicy,omitempty"`
+
+	// NetworkConfig replaces the global network config.
+	// +optional
+	NetworkConfig *NetworkConfig `json:"networkConfig,omitempty"`
+
+	// AuthConfig replaces the global auth config.
+	// +optional
+	AuthConfig *AuthConfig `json:"authConfig,omitempty"`
+
+	// SecurityConfig replaces the global security config.
+	// +optional
+	SecurityConfig *SecurityConfig `json:"securityConfig,omitempty"`
+}
+
+// AutoscaleConfigOverride holds the overridable fields of AutoscaleConfig.
+type AutoscaleConfigOverride struct {
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RPS *int `json:"rps,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CPU *int `json:"cpu,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Memory *int `json:"memory,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Concurrency *int `json:"concurrency,omitempty"`
+	// +kubebuilder:validation:Minimum=2
+	// +optional
+	ActivationScale *int `json:"activationScale,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicasLimit *int `json:"minReplicasLimit,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicasLimit *int `json:"maxReplicasLimit,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleDelay *int `json:"maxScaleDelay,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScaleToZeroRetention *int `json:"maxScaleToZeroRetention,omitempty"`
+	// RequireWarmReplica, when true, forces Capps in the selected namespaces to keep at least one warm replica,
+	// as if the namespaces were listed in the global warm namespaces.
+	// +optional
+	RequireWarmReplica *bool `json:"requireWarmReplica,omitempty"`
+}
+
+// CappConfigOverrideStatus defines the observed state of CappConfigOverride
+type CappConfigOverrideStatus struct{}
+
+// +kubebuilder:object:root=true
+
+// CappConfigOverride is the Schema for the cappconfigoverrides API.
+// Only CappConfigOverrides in the operator namespace are taken into account. Overrides matching the
+// same namespace are merged over the global CappConfig in name order, so later names take precedence.
+type CappConfigOverride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CappConfigOverrideSpec   `json:"spec,omitempty"`
+	Status CappConfigOverrideStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CappConfigOverrideList contains a list of CappConfigOverride
+type CappConfigOverrideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CappConfigOverride `json:"items"`
+}
@@ -43,7 +43,29 @@ type CappTemplate struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// CappRevisionStatus records how a revision differs from the revision before it.
+type CappRevisionStatus struct {
+	// PreviousRevisionNumber is the number of the revision this one was compared with.
+	// It is unset on the first revision of a Capp.
+	// +optional
+	PreviousRevisionNumber int `json:"previousRevisionNumber,omitempty"`
+
+	// ChangedFields lists the paths of the Capp fields whose values differ from the previous revision,
+	// ordered by path. Items of lists of named objects, such as containers, env variables, volumes and
+	// event sources, are addressed by name. Values are not recorded.
+	// +optional
+	ChangedFields []string `json:"changedFields,omitempty"`
+
+	// UpdatedBy is the user who made the change, as recorded on the Capp by the mutating webhook.
+	// +optional
+	UpdatedBy string `json:"updatedBy,omitempty"`
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Revision",type="integer",JSONPath=".spec.revisionNumber",description="revision number"
+// +kubebuilder:printcolumn:name="Updated By",type="string",JSONPath=".status.updatedBy",description="user who made the change"
+// +kubebuilder:printcolumn:name="Changed Fields",type="string",JSONPath=".status.changedFields",description="fields changed since the previous revision",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // CappRevision is the Schema for the CappRevisions API
 type CappRevision struct {
@@ -51,6 +73,9 @@ type CappRevision struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec CappRevisionSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status CappRevisionStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -40,6 +40,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
 		&Capp{}, &CappList{},
 		&CappConfig{}, &CappConfigList{},
+		&CappConfigOverride{}, &CappConfigOverrideList{},
 		&CappRevision{}, &CappRevisionList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
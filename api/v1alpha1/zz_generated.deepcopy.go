@@ -21,15 +21,35 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthConfig.
+func (in *AuthConfig) DeepCopy() *AuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscaleConfig) DeepCopyInto(out *AutoscaleConfig) {
 	*out = *in
+	if in.WarmNamespaces != nil {
+		in, out := &in.WarmNamespaces, &out.WarmNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscaleConfig.
@@ -42,6 +62,71 @@ func (in *AutoscaleConfig) DeepCopy() *AutoscaleConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscaleConfigOverride) DeepCopyInto(out *AutoscaleConfigOverride) {
+	*out = *in
+	if in.RPS != nil {
+		in, out := &in.RPS, &out.RPS
+		*out = new(int)
+		**out = **in
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(int)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(int)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int)
+		**out = **in
+	}
+	if in.ActivationScale != nil {
+		in, out := &in.ActivationScale, &out.ActivationScale
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinReplicasLimit != nil {
+		in, out := &in.MinReplicasLimit, &out.MinReplicasLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxReplicasLimit != nil {
+		in, out := &in.MaxReplicasLimit, &out.MaxReplicasLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxScaleDelay != nil {
+		in, out := &in.MaxScaleDelay, &out.MaxScaleDelay
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxScaleToZeroRetention != nil {
+		in, out := &in.MaxScaleToZeroRetention, &out.MaxScaleToZeroRetention
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequireWarmReplica != nil {
+		in, out := &in.RequireWarmReplica, &out.RequireWarmReplica
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscaleConfigOverride.
+func (in *AutoscaleConfigOverride) DeepCopy() *AutoscaleConfigOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscaleConfigOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Capp) DeepCopyInto(out *Capp) {
 	*out = *in
@@ -75,7 +160,7 @@ func (in *CappConfig) DeepCopyInto(out *CappConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfig.
@@ -128,17 +213,166 @@ func (in *CappConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CappConfigOverride) DeepCopyInto(out *CappConfigOverride) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigOverride.
+func (in *CappConfigOverride) DeepCopy() *CappConfigOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(CappConfigOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CappConfigOverride) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CappConfigOverrideList) DeepCopyInto(out *CappConfigOverrideList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CappConfigOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigOverrideList.
+func (in *CappConfigOverrideList) DeepCopy() *CappConfigOverrideList {
+	if in == nil {
+		return nil
+	}
+	out := new(CappConfigOverrideList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CappConfigOverrideList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CappConfigOverrideSpec) DeepCopyInto(out *CappConfigOverrideSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.AutoscaleConfig != nil {
+		in, out := &in.AutoscaleConfig, &out.AutoscaleConfig
+		*out = new(AutoscaleConfigOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultResources != nil {
+		in, out := &in.DefaultResources, &out.DefaultResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedHostnamePatterns != nil {
+		in, out := &in.AllowedHostnamePatterns, &out.AllowedHostnamePatterns
+		*out = make([]HostnamePattern, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.RevisionRetentionPeriod != nil {
+		in, out := &in.RevisionRetentionPeriod, &out.RevisionRetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxKafkaConsumers != nil {
+		in, out := &in.MaxKafkaConsumers, &out.MaxKafkaConsumers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkConfig != nil {
+		in, out := &in.NetworkConfig, &out.NetworkConfig
+		*out = new(NetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthConfig != nil {
+		in, out := &in.AuthConfig, &out.AuthConfig
+		*out = new(AuthConfig)
+		**out = **in
+	}
+	if in.SecurityConfig != nil {
+		in, out := &in.SecurityConfig, &out.SecurityConfig
+		*out = new(SecurityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigOverrideSpec.
+func (in *CappConfigOverrideSpec) DeepCopy() *CappConfigOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CappConfigOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CappConfigOverrideStatus) DeepCopyInto(out *CappConfigOverrideStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigOverrideStatus.
+func (in *CappConfigOverrideStatus) DeepCopy() *CappConfigOverrideStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CappConfigOverrideStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CappConfigSpec) DeepCopyInto(out *CappConfigSpec) {
 	*out = *in
 	out.DNSConfig = in.DNSConfig
-	out.AutoscaleConfig = in.AutoscaleConfig
+	in.AutoscaleConfig.DeepCopyInto(&out.AutoscaleConfig)
 	in.DefaultResources.DeepCopyInto(&out.DefaultResources)
 	if in.AllowedHostnamePatterns != nil {
 		in, out := &in.AllowedHostnamePatterns, &out.AllowedHostnamePatterns
 		*out = make([]HostnamePattern, len(*in))
 		copy(*out, *in)
 	}
+	if in.RevisionRetentionPeriod != nil {
+		in, out := &in.RevisionRetentionPeriod, &out.RevisionRetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.ImagePolicy.DeepCopyInto(&out.ImagePolicy)
+	in.NetworkConfig.DeepCopyInto(&out.NetworkConfig)
+	out.AuthConfig = in.AuthConfig
+	in.SecurityConfig.DeepCopyInto(&out.SecurityConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigSpec.
@@ -154,6 +388,13 @@ func (in *CappConfigSpec) DeepCopy() *CappConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CappConfigStatus) DeepCopyInto(out *CappConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappConfigStatus.
@@ -204,6 +445,7 @@ func (in *CappRevision) DeepCopyInto(out *CappRevision) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappRevision.
@@ -272,15 +514,42 @@ func (in *CappRevisionSpec) DeepCopy() *CappRevisionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CappRevisionStatus) DeepCopyInto(out *CappRevisionStatus) {
+	*out = *in
+	if in.ChangedFields != nil {
+		in, out := &in.ChangedFields, &out.ChangedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappRevisionStatus.
+func (in *CappRevisionStatus) DeepCopy() *CappRevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CappRevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CappSpec) DeepCopyInto(out *CappSpec) {
 	*out = *in
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
 	in.ScaleSpec.DeepCopyInto(&out.ScaleSpec)
 	in.ConfigurationSpec.DeepCopyInto(&out.ConfigurationSpec)
 	in.RouteSpec.DeepCopyInto(&out.RouteSpec)
 	out.LogSpec = in.LogSpec
 	in.VolumesSpec.DeepCopyInto(&out.VolumesSpec)
 	in.EventSourcesSpec.DeepCopyInto(&out.EventSourcesSpec)
+	in.SecretsSpec.DeepCopyInto(&out.SecretsSpec)
+	in.NetworkSpec.DeepCopyInto(&out.NetworkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CappSpec.
@@ -309,9 +578,15 @@ func (in *CappStatus) DeepCopyInto(out *CappStatus) {
 	in.RouteStatus.DeepCopyInto(&out.RouteStatus)
 	in.VolumesStatus.DeepCopyInto(&out.VolumesStatus)
 	in.EventingStatus.DeepCopyInto(&out.EventingStatus)
+	if in.SecretsStatus != nil {
+		in, out := &in.SecretsStatus, &out.SecretsStatus
+		*out = make([]ExternalSecretStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.ConfigStatus.DeepCopyInto(&out.ConfigStatus)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -358,6 +633,53 @@ func (in *CappTemplate) DeepCopy() *CappTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapVolume) DeepCopyInto(out *ConfigMapVolume) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]corev1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapVolume.
+func (in *ConfigMapVolume) DeepCopy() *ConfigMapVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigStatus) DeepCopyInto(out *ConfigStatus) {
+	*out = *in
+	if in.AppliedOverrides != nil {
+		in, out := &in.AppliedOverrides, &out.AppliedOverrides
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(CappConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigStatus.
+func (in *ConfigStatus) DeepCopy() *ConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
 	*out = *in
@@ -450,6 +772,69 @@ func (in *EventingStatus) DeepCopy() *EventingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecret) DeepCopyInto(out *ExternalSecret) {
+	*out = *in
+	out.StoreRef = in.StoreRef
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ExternalSecretEnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecret.
+func (in *ExternalSecret) DeepCopy() *ExternalSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretEnvVar) DeepCopyInto(out *ExternalSecretEnvVar) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretEnvVar.
+func (in *ExternalSecretEnvVar) DeepCopy() *ExternalSecretEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretStatus) DeepCopyInto(out *ExternalSecretStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretStatus.
+func (in *ExternalSecretStatus) DeepCopy() *ExternalSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostnamePattern) DeepCopyInto(out *HostnamePattern) {
 	*out = *in
@@ -465,6 +850,31 @@ func (in *HostnamePattern) DeepCopy() *HostnamePattern {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicy) DeepCopyInto(out *ImagePolicy) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DigestRequiredNamespaces != nil {
+		in, out := &in.DigestRequiredNamespaces, &out.DigestRequiredNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicy.
+func (in *ImagePolicy) DeepCopy() *ImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerRef) DeepCopyInto(out *IssuerRef) {
 	*out = *in
@@ -533,7 +943,7 @@ func (in *LoggingStatus) DeepCopyInto(out *LoggingStatus) {
 	in.SyslogNGOutput.DeepCopyInto(&out.SyslogNGOutput)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -555,11 +965,18 @@ func (in *NFSVolume) DeepCopyInto(out *NFSVolume) {
 	*out = *in
 	if in.Capacity != nil {
 		in, out := &in.Capacity, &out.Capacity
-		*out = make(v1.ResourceList, len(*in))
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]NFSVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSVolume.
@@ -572,6 +989,26 @@ func (in *NFSVolume) DeepCopy() *NFSVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NFSVolumeMount) DeepCopyInto(out *NFSVolumeMount) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSVolumeMount.
+func (in *NFSVolumeMount) DeepCopy() *NFSVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(NFSVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NFSVolumeStatus) DeepCopyInto(out *NFSVolumeStatus) {
 	*out = *in
@@ -588,6 +1025,121 @@ func (in *NFSVolumeStatus) DeepCopy() *NFSVolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
+	*out = *in
+	if in.SystemNamespaces != nil {
+		in, out := &in.SystemNamespaces, &out.SystemNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkConfig.
+func (in *NetworkConfig) DeepCopy() *NetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkEgressPeer) DeepCopyInto(out *NetworkEgressPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkEgressPeer.
+func (in *NetworkEgressPeer) DeepCopy() *NetworkEgressPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkEgressPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIngressPeer) DeepCopyInto(out *NetworkIngressPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIngressPeer.
+func (in *NetworkIngressPeer) DeepCopy() *NetworkIngressPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIngressPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkIngressPeer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]NetworkEgressPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCVolume) DeepCopyInto(out *PVCVolume) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCVolume.
+func (in *PVCVolume) DeepCopy() *PVCVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCVolumeStatus) DeepCopyInto(out *PVCVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCVolumeStatus.
+func (in *PVCVolumeStatus) DeepCopy() *PVCVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PingSourceConfiguration) DeepCopyInto(out *PingSourceConfiguration) {
 	*out = *in
@@ -619,6 +1171,26 @@ func (in *RevisionInfo) DeepCopy() *RevisionInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteAuth) DeepCopyInto(out *RouteAuth) {
+	*out = *in
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteAuth.
+func (in *RouteAuth) DeepCopy() *RouteAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
@@ -628,6 +1200,11 @@ func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(RouteAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpec.
@@ -676,6 +1253,21 @@ func (in *ScaleSpec) DeepCopyInto(out *ScaleSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InitialScale != nil {
+		in, out := &in.InitialScale, &out.InitialScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleToZeroRetentionSeconds != nil {
+		in, out := &in.ScaleToZeroRetentionSeconds, &out.ScaleToZeroRetentionSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowScaleToZero != nil {
+		in, out := &in.AllowScaleToZero, &out.AllowScaleToZero
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleSpec.
@@ -688,6 +1280,85 @@ func (in *ScaleSpec) DeepCopy() *ScaleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreRef) DeepCopyInto(out *SecretStoreRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreRef.
+func (in *SecretStoreRef) DeepCopy() *SecretStoreRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretVolume) DeepCopyInto(out *SecretVolume) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]corev1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVolume.
+func (in *SecretVolume) DeepCopy() *SecretVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsSpec) DeepCopyInto(out *SecretsSpec) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]ExternalSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsSpec.
+func (in *SecretsSpec) DeepCopy() *SecretsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityConfig) DeepCopyInto(out *SecurityConfig) {
+	*out = *in
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfig.
+func (in *SecurityConfig) DeepCopy() *SecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceConfiguration) DeepCopyInto(out *SourceConfiguration) {
 	*out = *in
@@ -744,6 +1415,27 @@ func (in *VolumesSpec) DeepCopyInto(out *VolumesSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PVCVolumes != nil {
+		in, out := &in.PVCVolumes, &out.PVCVolumes
+		*out = make([]PVCVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMapVolumes != nil {
+		in, out := &in.ConfigMapVolumes, &out.ConfigMapVolumes
+		*out = make([]ConfigMapVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecretVolumes != nil {
+		in, out := &in.SecretVolumes, &out.SecretVolumes
+		*out = make([]SecretVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumesSpec.
@@ -764,6 +1456,11 @@ func (in *VolumesStatus) DeepCopyInto(out *VolumesStatus) {
 		*out = make([]NFSVolumeStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.PVCVolumesStatus != nil {
+		in, out := &in.PVCVolumesStatus, &out.PVCVolumesStatus
+		*out = make([]PVCVolumeStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumesStatus.
@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-capp is a kubectl plugin for day-2 operations on Capps.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kubectlcapp"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(cappv1alpha1.AddToScheme(scheme))
+}
+
+// clusterOptions holds the flags selecting the cluster and namespace to work in.
+type clusterOptions struct {
+	loadingRules *clientcmd.ClientConfigLoadingRules
+	overrides    clientcmd.ConfigOverrides
+}
+
+func (o *clusterOptions) clientConfig() clientcmd.ClientConfig {
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(o.loadingRules, &o.overrides)
+}
+
+// namespace returns the namespace set with --namespace, or the one of the current kubeconfig context.
+func (o *clusterOptions) namespace() (string, error) {
+	namespace, _, err := o.clientConfig().Namespace()
+	return namespace, err
+}
+
+func (o *clusterOptions) client() (client.Client, error) {
+	config, err := o.clientConfig().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+func (o *clusterOptions) clientset() (kubernetes.Interface, error) {
+	config, err := o.clientConfig().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func (o *clusterOptions) getCapp(ctx context.Context, name string) (client.Client, cappv1alpha1.Capp, error) {
+	capp := cappv1alpha1.Capp{}
+	namespace, err := o.namespace()
+	if err != nil {
+		return nil, capp, err
+	}
+	c, err := o.client()
+	if err != nil {
+		return nil, capp, err
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &capp); err != nil {
+		return nil, capp, err
+	}
+	return c, capp, nil
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &clusterOptions{loadingRules: clientcmd.NewDefaultClientConfigLoadingRules()}
+
+	root := &cobra.Command{
+		Use:          "kubectl-capp",
+		Short:        "Inspect and operate Capps and the resources the operator creates for them",
+		SilenceUsage: true,
+	}
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.loadingRules.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	flags.StringVar(&opts.overrides.CurrentContext, "context", "", "The kubeconfig context to use.")
+	flags.StringVarP(&opts.overrides.Context.Namespace, "namespace", "n", "", "The namespace of the Capp.")
+
+	root.AddCommand(
+		newStatusCommand(opts),
+		newRevisionsCommand(opts),
+		newRollbackCommand(opts),
+		newStateCommand(opts, "enable", cappv1alpha1.CappStateEnabled),
+		newStateCommand(opts, "disable", cappv1alpha1.CappStateDisabled),
+		newLogsCommand(opts),
+	)
+	return root
+}
+
+func newStatusCommand(opts *clusterOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status NAME",
+		Short: "Show the Capp and its child resources with their readiness",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, capp, err := opts.getCapp(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return kubectlcapp.PrintStatus(cmd.OutOrStdout(), capp)
+		},
+	}
+}
+
+func newRevisionsCommand(opts *clusterOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revisions NAME [FROM TO]",
+		Short: "List the CappRevisions of a Capp, or show the diff between two of them",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 && len(args) != 3 {
+				return fmt.Errorf("accepts NAME or NAME FROM TO, received %d arguments", len(args))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, capp, err := opts.getCapp(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			revisions, err := kubectlcapp.ListRevisions(cmd.Context(), c, capp.Namespace, capp.Name)
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				return kubectlcapp.PrintRevisions(cmd.OutOrStdout(), revisions, time.Now())
+			}
+
+			from, err := revisionArg(revisions, args[1])
+			if err != nil {
+				return err
+			}
+			to, err := revisionArg(revisions, args[2])
+			if err != nil {
+				return err
+			}
+			return kubectlcapp.DiffRevisions(cmd.OutOrStdout(), from, to)
+		},
+	}
+}
+
+func revisionArg(revisions []cappv1alpha1.CappRevision, arg string) (cappv1alpha1.CappRevision, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return cappv1alpha1.CappRevision{}, fmt.Errorf("invalid revision number %q", arg)
+	}
+	return kubectlcapp.FindRevision(revisions, number)
+}
+
+func newRollbackCommand(opts *clusterOptions) *cobra.Command {
+	var toRevision int
+	cmd := &cobra.Command{
+		Use:   "rollback NAME",
+		Short: "Restore a Capp from one of its CappRevisions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, capp, err := opts.getCapp(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			restored, err := kubectlcapp.Rollback(cmd.Context(), c, capp.Namespace, capp.Name, toRevision)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "capp/%s rolled back to revision %d\n", capp.Name, restored)
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&toRevision, "to-revision", 0, "The revision to roll back to. Defaults to the revision before the latest one.")
+	return cmd
+}
+
+func newStateCommand(opts *clusterOptions, use, state string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " NAME",
+		Short: "Set the state of a Capp to " + state,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, capp, err := opts.getCapp(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if err := kubectlcapp.SetState(cmd.Context(), c, capp.Namespace, capp.Name, state); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "capp/%s %s\n", capp.Name, state)
+			return err
+		},
+	}
+}
+
+func newLogsCommand(opts *clusterOptions) *cobra.Command {
+	var logOpts kubectlcapp.LogOptions
+	var tail int64
+	cmd := &cobra.Command{
+		Use:   "logs NAME",
+		Short: "Print the logs of the pods of the current Knative revision of a Capp",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, capp, err := opts.getCapp(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			clientset, err := opts.clientset()
+			if err != nil {
+				return err
+			}
+			if tail >= 0 {
+				logOpts.TailLines = &tail
+			}
+			return kubectlcapp.StreamLogs(cmd.Context(), clientset, cmd.OutOrStdout(), capp, logOpts)
+		},
+	}
+	cmd.Flags().BoolVarP(&logOpts.Follow, "follow", "f", true, "Keep streaming new log lines.")
+	cmd.Flags().StringVarP(&logOpts.Container, "container", "c", "", "The container to print logs from. Defaults to the first container of the Capp.")
+	cmd.Flags().Int64Var(&tail, "tail", -1, "The number of past lines to print per pod. All lines are printed if negative.")
+	return cmd
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -27,10 +29,14 @@ import (
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
 	dnsrecordv1alpha1 "github.com/dana-team/provider-dns-v2/apis/namespaced/record/v1alpha1"
+	dnsv1beta1 "github.com/dana-team/provider-dns-v2/apis/namespaced/v1beta1"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	cappcontroller "github.com/dana-team/container-app-operator/internal/kinds/capp/controllers"
+	ccontroller "github.com/dana-team/container-app-operator/internal/kinds/cappconfig/controllers"
 	crcontroller "github.com/dana-team/container-app-operator/internal/kinds/capprevision/controllers"
+	cappmetrics "github.com/dana-team/container-app-operator/internal/metrics"
+	"github.com/dana-team/container-app-operator/internal/tracing"
 	webhooks "github.com/dana-team/container-app-operator/internal/webhook/rcs/v1alpha1"
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
 	"github.com/go-logr/zapr"
@@ -50,6 +56,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	runtimezap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	// +kubebuilder:scaffold:imports
@@ -69,6 +76,7 @@ func init() {
 	utilruntime.Must(nfspvcv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(cmapi.AddToScheme(scheme))
 	utilruntime.Must(dnsrecordv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(dnsv1beta1.SchemeBuilder.AddToScheme(scheme))
 	utilruntime.Must(eventingv1.AddToScheme(scheme))
 	utilruntime.Must(kafkasourcev1.AddToScheme(scheme))
 
@@ -83,6 +91,14 @@ func initEcsLogger(level zapcore.Level) {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		if err := runRender(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", renderCommand, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -91,6 +107,8 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var tracingOpts tracing.Options
+	var resourceManagerParallelism int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -103,6 +121,14 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&resourceManagerParallelism, "resource-manager-parallelism", cappcontroller.DefaultResourceManagerParallelism,
+		"The maximum number of independent resource managers run concurrently for a single Capp.")
+	flag.StringVar(&tracingOpts.Endpoint, "otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector to export traces to. Tracing is disabled if empty.")
+	flag.BoolVar(&tracingOpts.Insecure, "otlp-insecure", false,
+		"If set, traces are exported to the OTLP collector without TLS.")
+	flag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"The fraction of new traces that are sampled, between 0 and 1.")
 	flag.Parse()
 
 	lvl := zapcore.InfoLevel
@@ -114,6 +140,12 @@ func main() {
 	} else {
 		ctrl.SetLogger(runtimezap.New(runtimezap.Level(lvl)))
 	}
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	metricsServerOptions := metricsserver.Options{
 		BindAddress:   metricsAddr,
 		SecureServing: secureMetrics,
@@ -156,9 +188,10 @@ func main() {
 	}
 
 	if err = (&cappcontroller.CappReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		EventRecorder: mgr.GetEventRecorder("container-app-controller"),
+		Client:                     mgr.GetClient(),
+		Scheme:                     mgr.GetScheme(),
+		EventRecorder:              mgr.GetEventRecorder("container-app-controller"),
+		ResourceManagerParallelism: resourceManagerParallelism,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Capp")
 		os.Exit(1)
@@ -173,6 +206,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&ccontroller.CappConfigReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: mgr.GetEventRecorder("cappconfig-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CappConfig")
+		os.Exit(1)
+	}
+
+	ctrlmetrics.Registry.MustRegister(cappmetrics.NewCappCollector(mgr.GetClient()))
+
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		hookServer := mgr.GetWebhookServer()
@@ -203,4 +247,8 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "problem flushing traces")
+	}
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	webhooks "github.com/dana-team/container-app-operator/internal/webhook/rcs/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	renderCommand    = "render"
+	defaultNamespace = "default"
+)
+
+// runRender prints, as a multi-document YAML stream, the child resources the operator would create
+// for the Capp and CappConfig read from the files named in args.
+func runRender(ctx context.Context, args []string, out io.Writer) error {
+	var cappPath, cappConfigPath string
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	flags.StringVar(&cappPath, "capp", "", "Path to the Capp YAML to render.")
+	flags.StringVar(&cappConfigPath, "cappconfig", "", "Path to the CappConfig YAML to render the Capp with.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if cappPath == "" || cappConfigPath == "" {
+		return errors.New("both --capp and --cappconfig are required")
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	capp := cappv1alpha1.Capp{}
+	if err := decodeFile(decoder, cappPath, &capp); err != nil {
+		return err
+	}
+	cappConfig := cappv1alpha1.CappConfig{}
+	if err := decodeFile(decoder, cappConfigPath, &cappConfig); err != nil {
+		return err
+	}
+	if capp.Namespace == "" {
+		capp.Namespace = defaultNamespace
+	}
+	webhooks.SetDefaults(&capp, &cappConfig)
+
+	rendered, err := rmanagers.RenderResources(ctx, scheme, capp, &cappConfig)
+	if err != nil {
+		return err
+	}
+	for i, obj := range rendered {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", obj.GetName(), err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeFile(decoder runtime.Decoder, path string, into runtime.Object) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, _, err := decoder.Decode(data, nil, into); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
@@ -11,12 +11,32 @@ var (
 	CappNamespaceKey  = CappAPIGroup + "/parent-capp-ns"
 	CappResourceKey   = CappAPIGroup + "/parent-capp"
 	ManagedByLabelKey = CappAPIGroup + "/managed-by"
+
+	// DriftCorrectionAnnotationKey on a Capp set to DriftCorrectionPaused stops the operator from
+	// reverting out-of-band changes to its child resources.
+	DriftCorrectionAnnotationKey = CappAPIGroup + "/drift-correction"
+	// AppliedSpecHashAnnotationKey on a child resource holds the hash of the spec the operator last applied.
+	AppliedSpecHashAnnotationKey = CappAPIGroup + "/applied-spec-hash"
+	// LastUpdatedByAnnotationKey on a Capp holds the username of whoever last created or updated it.
+	LastUpdatedByAnnotationKey = CappAPIGroup + "/last-updated-by"
+	// ConfigHashAnnotationKey on the revision template of a Knative Service holds the hash of the content
+	// of the ConfigMaps and Secrets mounted by the Capp, so that changing it rolls out a new revision.
+	ConfigHashAnnotationKey = CappAPIGroup + "/config-hash"
+	// ReclaimPolicyAnnotationKey on a volume created for a Capp holds the reclaim policy of the volume,
+	// so that it is known once the volume is removed from the Capp.
+	ReclaimPolicyAnnotationKey = CappAPIGroup + "/reclaim-policy"
+	// RetainedFromAnnotationKey on a retained volume holds the name of the Capp it was created for.
+	RetainedFromAnnotationKey = CappAPIGroup + "/retained-from"
+	// ProtectedRevisionLabelKey on a CappRevision set to "true" keeps it from being pruned.
+	ProtectedRevisionLabelKey = CappAPIGroup + "/protected"
 )
 
 const (
 	CappConfigName = "capp-config"
 	CappNS         = "container-app-operator-system"
 	CappKey        = "capp"
+
+	DriftCorrectionPaused = "paused"
 )
 
 // ManagedResourceLabels returns labels used for child resources reconciled from a Capp.
@@ -15,6 +15,9 @@ import (
 
 	loggingv1beta1 "github.com/kube-logging/logging-operator/pkg/sdk/logging/api/v1beta1"
 
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -26,9 +29,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/status"
+	"github.com/dana-team/container-app-operator/internal/metrics"
+	"github.com/dana-team/container-app-operator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -46,6 +54,10 @@ import (
 const (
 	cappControllerName = "CappController"
 	RequeueTime        = 5 * time.Second
+
+	// DefaultResourceManagerParallelism is the number of resource managers run concurrently for a Capp
+	// when ResourceManagerParallelism is not set.
+	DefaultResourceManagerParallelism = 4
 )
 
 // CappReconciler reconciles a Capp object
@@ -53,12 +65,26 @@ type CappReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	EventRecorder events.EventRecorder
+	// ResourceManagerParallelism bounds the number of independent resource managers run concurrently for a Capp.
+	ResourceManagerParallelism int
+	// externalSecretsInstalled reports whether the ExternalSecret CRD of the External Secrets Operator was
+	// installed when the controller was set up.
+	externalSecretsInstalled bool
+}
+
+func (r *CappReconciler) resourceManagerParallelism() int {
+	if r.ResourceManagerParallelism > 0 {
+		return r.ResourceManagerParallelism
+	}
+	return DefaultResourceManagerParallelism
 }
 
 // +kubebuilder:rbac:groups=rcs.dana.io,resources=capps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rcs.dana.io,resources=capps/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=rcs.dana.io,resources=capps/finalizers,verbs=update
 // +kubebuilder:rbac:groups="rcs.dana.io",resources=cappconfigs,verbs=get;list;watch;
+// +kubebuilder:rbac:groups="rcs.dana.io",resources=cappconfigoverrides,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=domainmappings,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=revisions,verbs=get;list;watch;update;create
@@ -69,15 +95,29 @@ type CappReconciler struct {
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;update;create;patch
 // +kubebuilder:rbac:groups="events.k8s.io",resources=events,verbs=get;list;watch;update;create;patch;
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;update;create;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups="nfspvc.dana.io",resources=nfspvcs,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups="record.dns-v2.m.crossplane.io",resources=cnamerecords,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups="cert-manager.io",resources=certificates,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups="sources.knative.dev",resources=pingsources,verbs=get;list;watch;update;create;delete
 // +kubebuilder:rbac:groups="sources.knative.dev",resources=kafkasources,verbs=get;list;watch;update;create;delete
+// +kubebuilder:rbac:groups="external-secrets.io",resources=externalsecrets,verbs=get;list;watch;update;create;patch;delete
+// +kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;update;create;patch;delete
 
 // SetupWithManager sets up the controller with the Manager.
+// ExternalSecrets are only watched and managed if the External Secrets Operator is installed.
 func (r *CappReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	installed, err := kindInstalled(mgr.GetRESTMapper(), rmanagers.ExternalSecretGVK)
+	if err != nil {
+		return fmt.Errorf("failed to look up %s: %w", rmanagers.ExternalSecretGVK.Kind, err)
+	}
+	r.externalSecretsInstalled = installed
+	if !installed {
+		mgr.GetLogger().Info("External Secrets Operator is not installed, secretsSpec of Capps will not be synced")
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&cappv1alpha1.Capp{},
 			builder.WithPredicates(
 				predicate.Or(
@@ -118,6 +158,22 @@ func (r *CappReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findCappFromEvent),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
+		Watches(
+			&corev1.PersistentVolumeClaim{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappFromLabels),
+			builder.WithPredicates(persistentVolumeClaimWatchPredicate())).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappsUsingObject),
+			builder.WithPredicates(mountedConfigWatchPredicate())).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappsUsingObject),
+			builder.WithPredicates(mountedConfigWatchPredicate())).
+		Watches(
+			&networkingv1.NetworkPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappFromLabels),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(
 			&sourcesv1.PingSource{},
 			handler.EnqueueRequestsFromMapFunc(r.findCappFromLabels),
@@ -130,7 +186,32 @@ func (r *CappReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&cappv1alpha1.CappConfig{},
 			handler.EnqueueRequestsFromMapFunc(r.findCappsForCappConfig),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+		Watches(
+			&cappv1alpha1.CappConfigOverride{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappsForCappConfig),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findCappsInNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	if r.externalSecretsInstalled {
+		controllerBuilder = controllerBuilder.Watches(
+			rmanagers.NewExternalSecret(),
+			handler.EnqueueRequestsFromMapFunc(r.findCappFromLabels),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// kindInstalled reports whether the API server serves the kind of gvk.
+func kindInstalled(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (bool, error) {
+	if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
 // knativeServiceWatchPredicate: spec changes (generation) or revision lifecycle status on the Service.
@@ -289,6 +370,39 @@ func certificateWatchPredicate() predicate.Predicate {
 	)
 }
 
+// persistentVolumeClaimWatchPredicate: the claim phase changed, as when it is bound.
+func persistentVolumeClaimWatchPredicate() predicate.Predicate {
+	return predicate.TypedFuncs[client.Object]{
+		UpdateFunc: func(e event.TypedUpdateEvent[client.Object]) bool {
+			oldObj, okOld := e.ObjectOld.(*corev1.PersistentVolumeClaim)
+			newObj, okNew := e.ObjectNew.(*corev1.PersistentVolumeClaim)
+			if !okOld || !okNew {
+				return false
+			}
+			return oldObj.Status.Phase != newObj.Status.Phase
+		},
+	}
+}
+
+// mountedConfigWatchPredicate: the content of a ConfigMap or Secret changed.
+func mountedConfigWatchPredicate() predicate.Predicate {
+	return predicate.TypedFuncs[client.Object]{
+		UpdateFunc: func(e event.TypedUpdateEvent[client.Object]) bool {
+			switch oldObj := e.ObjectOld.(type) {
+			case *corev1.ConfigMap:
+				newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+				return ok && (!equality.Semantic.DeepEqual(oldObj.Data, newObj.Data) ||
+					!equality.Semantic.DeepEqual(oldObj.BinaryData, newObj.BinaryData))
+			case *corev1.Secret:
+				newObj, ok := e.ObjectNew.(*corev1.Secret)
+				return ok && !equality.Semantic.DeepEqual(oldObj.Data, newObj.Data)
+			default:
+				return false
+			}
+		},
+	}
+}
+
 func certificateConditions(conds []cmapi.CertificateCondition) []conditionPair {
 	out := make([]conditionPair, len(conds))
 	for i, c := range conds {
@@ -307,7 +421,7 @@ func (r *CappReconciler) findCappFromEvent(ctx context.Context, object client.Ob
 	return []reconcile.Request{request}
 }
 
-// findCappsForCappConfig enqueues every Capp in the cluster when CappConfig changes.
+// findCappsForCappConfig enqueues every Capp in the cluster when CappConfig or a CappConfigOverride changes.
 func (r *CappReconciler) findCappsForCappConfig(ctx context.Context, _ client.Object) []reconcile.Request {
 	logger := log.FromContext(ctx)
 
@@ -330,6 +444,60 @@ func (r *CappReconciler) findCappsForCappConfig(ctx context.Context, _ client.Ob
 	return requests
 }
 
+// findCappsInNamespace enqueues every Capp in a namespace whose labels changed, since
+// the labels decide which CappConfigOverrides apply to it.
+func (r *CappReconciler) findCappsInNamespace(ctx context.Context, object client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	cappList := cappv1alpha1.CappList{}
+	if err := r.List(ctx, &cappList, client.InNamespace(object.GetName())); err != nil {
+		logger.Error(err, "failed to list Capps for namespace change")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(cappList.Items))
+	for _, capp := range cappList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      capp.Name,
+				Namespace: capp.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+// findCappsUsingObject enqueues every Capp in the namespace of a ConfigMap or Secret that uses it,
+// so that a change to its content rolls out a new revision.
+func (r *CappReconciler) findCappsUsingObject(ctx context.Context, object client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	cappList := cappv1alpha1.CappList{}
+	if err := r.List(ctx, &cappList, client.InNamespace(object.GetNamespace())); err != nil {
+		logger.Error(err, "failed to list Capps for mounted config change")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, capp := range cappList.Items {
+		var uses bool
+		switch object.(type) {
+		case *corev1.ConfigMap:
+			uses = rmanagers.MountsConfigMap(capp, object.GetName())
+		case *corev1.Secret:
+			uses = rmanagers.UsesSecret(capp, object.GetName())
+		}
+		if uses {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: capp.Name, Namespace: capp.Namespace},
+			})
+		}
+	}
+
+	return requests
+}
+
 // findCappFromLabels finds the owner Capp of a resource based on labels.
 func (r *CappReconciler) findCappFromLabels(ctx context.Context, object client.Object) []reconcile.Request {
 	labels := object.GetLabels()
@@ -346,7 +514,10 @@ func (r *CappReconciler) findCappFromLabels(ctx context.Context, object client.O
 	return []reconcile.Request{request}
 }
 
-func (r *CappReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *CappReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "Capp.Reconcile", tracing.CappAttributes(req.Namespace, req.Name)...)
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx).WithValues("CappName", req.Name, "CappNamespace", req.Namespace)
 	logger.Info("Starting Reconcile")
 	capp := cappv1alpha1.Capp{}
@@ -360,7 +531,7 @@ func (r *CappReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	rmClient := rclient.ResourceManagerClient{K8sClient: r.Client, Log: logger}
 
-	cappConfig, err := rmanagers.GetCappConfig(ctx, r.Client)
+	cappConfig, appliedOverrides, err := rmanagers.GetEffectiveCappConfig(ctx, r.Client, capp.Namespace)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get CappConfig: %w", err)
 	}
@@ -368,13 +539,18 @@ func (r *CappReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	resourceManagers := []rmanagers.ResourceManagerEntry{
 		{Name: rmanagers.KnativeService, Manager: rmanagers.KnativeServiceManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}},
 		{Name: rmanagers.NfsPvc, Manager: rmanagers.NFSPVCManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
+		{Name: rmanagers.PersistentVolumeClaim, Manager: rmanagers.PVCManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
+		{Name: rmanagers.NetworkPolicy, Manager: rmanagers.NetworkPolicyManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}},
 		{Name: rmanagers.SyslogNGOutput, Manager: rmanagers.SyslogNGOutputManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
-		{Name: rmanagers.SyslogNGFlow, Manager: rmanagers.SyslogNGFlowManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
+		{Name: rmanagers.SyslogNGFlow, Manager: rmanagers.SyslogNGFlowManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}, DependsOn: []string{rmanagers.SyslogNGOutput}},
 		{Name: rmanagers.Certificate, Manager: rmanagers.CertificateManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}},
-		{Name: rmanagers.DomainMapping, Manager: rmanagers.DomainMappingManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}},
+		{Name: rmanagers.DomainMapping, Manager: rmanagers.DomainMappingManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}, DependsOn: []string{rmanagers.KnativeService, rmanagers.Certificate}},
 		{Name: rmanagers.DNSRecord, Manager: rmanagers.DNSRecordManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder, CappConfig: cappConfig}},
-		{Name: rmanagers.PingSource, Manager: rmanagers.PingSourceManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
-		{Name: rmanagers.KafkaSource, Manager: rmanagers.KafkaSourceManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}},
+		{Name: rmanagers.PingSource, Manager: rmanagers.PingSourceManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}, DependsOn: []string{rmanagers.KnativeService}},
+		{Name: rmanagers.KafkaSource, Manager: rmanagers.KafkaSourceManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}, DependsOn: []string{rmanagers.KnativeService}},
+	}
+	if r.externalSecretsInstalled {
+		resourceManagers = append(resourceManagers, rmanagers.ResourceManagerEntry{Name: rmanagers.ExternalSecret, Manager: rmanagers.ExternalSecretManager{ResourceManagerClient: rmClient, EventRecorder: r.EventRecorder}})
 	}
 
 	deleted, err := handleResourceDeletion(ctx, capp, rmClient, resourceManagers)
@@ -390,27 +566,48 @@ func (r *CappReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, fmt.Errorf("failed to ensure finalizer in Capp: %w", err)
 	}
 
-	if err := r.SyncApplication(ctx, capp, resourceManagers, cappConfig, logger); err != nil {
+	if err := r.SyncApplication(ctx, capp, resourceManagers, cappConfig, appliedOverrides, logger); err != nil {
 		if hasConflictError(err) {
 			logger.Info(fmt.Sprintf("Conflict detected, requeuing: %s", err.Error()))
 			return ctrl.Result{RequeueAfter: RequeueTime}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to sync Capp: %w", err)
 	}
+
+	resizing, err := status.NFSVolumesResizing(ctx, r.Client, capp)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check NFS volumes resize: %w", err)
+	}
+	if resizing {
+		return ctrl.Result{RequeueAfter: RequeueTime}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
 // SyncApplication manages the lifecycle of Capp.
 // It ensures all manifests are applied according to the specification and synchronizes the status accordingly.
-func (r *CappReconciler) SyncApplication(ctx context.Context, capp cappv1alpha1.Capp, resourceManagers []rmanagers.ResourceManagerEntry, cappConfig *cappv1alpha1.CappConfig, logger logr.Logger) error {
-	var syncErrors []error
-	for _, entry := range resourceManagers {
-		if err := entry.Manager.Manage(ctx, capp); err != nil {
-			syncErrors = append(syncErrors, fmt.Errorf("%s: %w", entry.Name, err))
-		}
+// While the Capp is paused, only the status is synchronized.
+func (r *CappReconciler) SyncApplication(ctx context.Context, capp cappv1alpha1.Capp, resourceManagers []rmanagers.ResourceManagerEntry, cappConfig *cappv1alpha1.CappConfig, appliedOverrides []string, logger logr.Logger) error {
+	if capp.Spec.Paused {
+		logger.Info("Capp is paused, skipping resource managers")
+		return status.SyncStatus(ctx, capp, logger, r.Client, rmanagers.ManagerMap(resourceManagers), cappConfig, appliedOverrides, nil, nil)
 	}
 
-	if err := status.SyncStatus(ctx, capp, logger, r.Client, rmanagers.ManagerMap(resourceManagers), cappConfig, syncErrors); err != nil {
+	driftReport := &rmanagers.DriftReport{}
+	ctx = rmanagers.WithDriftReport(ctx, driftReport)
+	syncErrors := rmanagers.RunInDependencyOrder(ctx, resourceManagers, r.resourceManagerParallelism(), func(ctx context.Context, entry rmanagers.ResourceManagerEntry) error {
+		ctx, span := tracing.Start(ctx, "ResourceManager.Manage", attribute.String("capp.resource_manager", entry.Name))
+		start := time.Now()
+		err := entry.Manager.Manage(ctx, *capp.DeepCopy())
+		metrics.ObserveResourceManagerSync(entry.Name, time.Since(start), err)
+		tracing.End(span, err)
+		if err != nil {
+			return fmt.Errorf("%s: %w", entry.Name, err)
+		}
+		return nil
+	})
+
+	if err := status.SyncStatus(ctx, capp, logger, r.Client, rmanagers.ManagerMap(resourceManagers), cappConfig, appliedOverrides, syncErrors, driftReport.Drifts()); err != nil {
 		return err
 	}
 
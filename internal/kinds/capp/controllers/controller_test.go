@@ -10,14 +10,20 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
 	dnsrecordv1alpha1 "github.com/dana-team/provider-dns-v2/apis/namespaced/record/v1alpha1"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	kafkasourcev1 "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	knativeapis "knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -384,6 +390,46 @@ func TestCnameRecordWatchPredicate(t *testing.T) {
 	}
 }
 
+func TestPersistentVolumeClaimWatchPredicate(t *testing.T) {
+	pred := persistentVolumeClaimWatchPredicate()
+
+	makePVC := func(phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: phase}}
+	}
+
+	t.Run("delete always triggers", func(t *testing.T) {
+		e := event.DeleteEvent{Object: makePVC(corev1.ClaimBound)}
+		assert.True(t, pred.Delete(e))
+	})
+
+	updateTests := []struct {
+		name     string
+		oldObj   client.Object
+		newObj   client.Object
+		expected bool
+	}{
+		{
+			name:     "stable when phase unchanged",
+			oldObj:   makePVC(corev1.ClaimPending),
+			newObj:   makePVC(corev1.ClaimPending),
+			expected: false,
+		},
+		{
+			name:     "triggers when the claim is bound",
+			oldObj:   makePVC(corev1.ClaimPending),
+			newObj:   makePVC(corev1.ClaimBound),
+			expected: true,
+		},
+	}
+
+	for _, tt := range updateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event.UpdateEvent{ObjectOld: tt.oldObj, ObjectNew: tt.newObj}
+			assert.Equal(t, tt.expected, pred.Update(e))
+		})
+	}
+}
+
 func TestKnativeServiceWatchPredicate(t *testing.T) {
 	pred := knativeServiceWatchPredicate()
 
@@ -494,6 +540,92 @@ func TestFindCappsForCappConfig(t *testing.T) {
 	}
 }
 
+func TestFindCappsInNamespace(t *testing.T) {
+	ctx := context.Background()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: nsName1}}
+
+	r := &CappReconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(
+		&cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameA, Namespace: nsName1}},
+		&cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameB, Namespace: nsName2}},
+		&cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameC, Namespace: nsName1}},
+	).Build()}
+
+	result := r.findCappsInNamespace(ctx, namespace)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: cappNameA, Namespace: nsName1}},
+		{NamespacedName: types.NamespacedName{Name: cappNameC, Namespace: nsName1}},
+	}, result)
+}
+
+func TestFindCappsUsingObject(t *testing.T) {
+	ctx := context.Background()
+	const configName = "app-config"
+
+	mountsConfigMap := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameA, Namespace: nsName1}}
+	mountsConfigMap.Spec.VolumesSpec.ConfigMapVolumes = []cappv1alpha1.ConfigMapVolume{{Name: "config", ConfigMapName: configName}}
+	mountsSecret := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameB, Namespace: nsName1}}
+	mountsSecret.Spec.VolumesSpec.SecretVolumes = []cappv1alpha1.SecretVolume{{Name: "config", SecretName: configName}}
+	otherNamespace := mountsConfigMap.DeepCopy()
+	otherNamespace.Namespace = nsName2
+	usesExternalSecret := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappNameC, Namespace: nsName1}}
+	usesExternalSecret.Spec.SecretsSpec.Secrets = []cappv1alpha1.ExternalSecret{{Name: "db"}}
+
+	r := &CappReconciler{Client: fake.NewClientBuilder().WithScheme(newScheme()).
+		WithObjects(mountsConfigMap, mountsSecret, otherNamespace, usesExternalSecret).Build()}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configName, Namespace: nsName1}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: cappNameA, Namespace: nsName1}},
+	}, r.findCappsUsingObject(ctx, configMap))
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: configName, Namespace: nsName1}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: cappNameB, Namespace: nsName1}},
+	}, r.findCappsUsingObject(ctx, secret))
+
+	syncedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: cappNameC + "-db", Namespace: nsName1}}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: cappNameC, Namespace: nsName1}},
+	}, r.findCappsUsingObject(ctx, syncedSecret))
+}
+
+func TestMountedConfigWatchPredicate(t *testing.T) {
+	pred := mountedConfigWatchPredicate()
+
+	updateTests := []struct {
+		name     string
+		oldObj   client.Object
+		newObj   client.Object
+		expected bool
+	}{
+		{
+			name:     "stable when only ConfigMap metadata changes",
+			oldObj:   &corev1.ConfigMap{Data: map[string]string{"key": "a"}},
+			newObj:   &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"x": "y"}}, Data: map[string]string{"key": "a"}},
+			expected: false,
+		},
+		{
+			name:     "triggers when ConfigMap data changes",
+			oldObj:   &corev1.ConfigMap{Data: map[string]string{"key": "a"}},
+			newObj:   &corev1.ConfigMap{Data: map[string]string{"key": "b"}},
+			expected: true,
+		},
+		{
+			name:     "triggers when Secret data changes",
+			oldObj:   &corev1.Secret{Data: map[string][]byte{"key": []byte("a")}},
+			newObj:   &corev1.Secret{Data: map[string][]byte{"key": []byte("b")}},
+			expected: true,
+		},
+	}
+
+	for _, tt := range updateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event.UpdateEvent{ObjectOld: tt.oldObj, ObjectNew: tt.newObj}
+			assert.Equal(t, tt.expected, pred.Update(e))
+		})
+	}
+}
+
 func TestFindCappFromEvent(t *testing.T) {
 	r := &CappReconciler{}
 	ctx := context.Background()
@@ -563,3 +695,61 @@ func TestFindCappFromLabels(t *testing.T) {
 		})
 	}
 }
+
+// manageRecordingManager is not required and counts its Manage calls.
+type manageRecordingManager struct {
+	stubResourceManager
+	managed *int
+}
+
+func (m manageRecordingManager) Manage(_ context.Context, _ cappv1alpha1.Capp) error {
+	*m.managed++
+	return nil
+}
+
+func (m manageRecordingManager) IsRequired(_ cappv1alpha1.Capp) bool { return false }
+
+func TestSyncApplicationPaused(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := newScheme()
+	utilruntime.Must(sourcesv1.AddToScheme(scheme))
+	utilruntime.Must(kafkasourcev1.AddToScheme(scheme))
+
+	capp := newCapp()
+	capp.Spec.Paused = true
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(capp).WithStatusSubresource(capp).Build()
+
+	managed := 0
+	var entries []rmanagers.ResourceManagerEntry
+	for _, name := range []string{
+		rmanagers.KnativeService, rmanagers.SyslogNGFlow, rmanagers.DomainMapping,
+		rmanagers.DNSRecord, rmanagers.Certificate, rmanagers.NfsPvc, rmanagers.PersistentVolumeClaim, rmanagers.PingSource, rmanagers.KafkaSource,
+	} {
+		entries = append(entries, rmanagers.ResourceManagerEntry{Name: name, Manager: manageRecordingManager{managed: &managed}})
+	}
+
+	r := &CappReconciler{Client: k8sClient}
+	cappConfig := &cappv1alpha1.CappConfig{}
+	assert.NoError(t, r.SyncApplication(ctx, *capp, entries, cappConfig, nil, logr.Discard()))
+	assert.Zero(t, managed)
+
+	got := &cappv1alpha1.Capp{}
+	assert.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: nsName}, got))
+	condition := meta.FindStatusCondition(got.Status.Conditions, cappv1alpha1.CappConditionPaused)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	}
+}
+
+func TestKindInstalled(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	installed, err := kindInstalled(mapper, rmanagers.ExternalSecretGVK)
+	assert.NoError(t, err)
+	assert.False(t, installed, "kind without a mapping should not be installed")
+
+	mapper.Add(rmanagers.ExternalSecretGVK, meta.RESTScopeNamespace)
+	installed, err = kindInstalled(mapper, rmanagers.ExternalSecretGVK)
+	assert.NoError(t, err)
+	assert.True(t, installed)
+}
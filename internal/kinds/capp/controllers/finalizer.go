@@ -29,8 +29,13 @@ func removeFinalizer(ctx context.Context, capp cappv1alpha1.Capp, rmClient rclie
 	return rmClient.UpdateResource(ctx, &capp)
 }
 
+// finalizeCapp cleans up the resources of every manager sequentially, in reverse dependency order.
 func finalizeCapp(ctx context.Context, capp cappv1alpha1.Capp, resourceManagers []rmanagers.ResourceManagerEntry) error {
-	for _, entry := range slices.Backward(resourceManagers) {
+	ordered, err := rmanagers.TopologicalOrder(resourceManagers)
+	if err != nil {
+		return err
+	}
+	for _, entry := range slices.Backward(ordered) {
 		if err := entry.Manager.CleanUp(ctx, capp); err != nil {
 			return err
 		}
@@ -31,6 +31,18 @@ func (s stubResourceManager) CleanUp(_ context.Context, _ cappv1alpha1.Capp) err
 }
 func (s stubResourceManager) IsRequired(_ cappv1alpha1.Capp) bool { return true }
 
+// orderRecordingManager appends its name to cleanedUp when cleaned up.
+type orderRecordingManager struct {
+	stubResourceManager
+	name      string
+	cleanedUp *[]string
+}
+
+func (o orderRecordingManager) CleanUp(_ context.Context, _ cappv1alpha1.Capp) error {
+	*o.cleanedUp = append(*o.cleanedUp, o.name)
+	return nil
+}
+
 const (
 	cappName = "test-capp"
 	nsName   = "test-ns"
@@ -195,3 +207,23 @@ func TestHandleResourceDeletion(t *testing.T) {
 		assert.False(t, deleted)
 	})
 }
+
+func TestFinalizeCapp(t *testing.T) {
+	var cleanedUp []string
+	newEntry := func(name string, dependsOn ...string) rmanagers.ResourceManagerEntry {
+		return rmanagers.ResourceManagerEntry{
+			Name:      name,
+			Manager:   orderRecordingManager{name: name, cleanedUp: &cleanedUp},
+			DependsOn: dependsOn,
+		}
+	}
+
+	managers := []rmanagers.ResourceManagerEntry{
+		newEntry(rmanagers.DomainMapping, rmanagers.Certificate),
+		newEntry(rmanagers.Certificate),
+		newEntry(rmanagers.PingSource),
+	}
+
+	assert.NoError(t, finalizeCapp(context.Background(), *newCapp(cappCleanupFinalizer), managers))
+	assert.Equal(t, []string{rmanagers.PingSource, rmanagers.DomainMapping, rmanagers.Certificate}, cleanedUp)
+}
@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	defaultRegistry  = "docker.io"
+	defaultNamespace = "library"
+	latestTag        = "latest"
+)
+
+// ValidateImagePolicy checks every container and init container image of the Capp against the image policy
+// and returns an aggregate of field-pathed errors, one per offending image.
+func ValidateImagePolicy(capp cappv1alpha1.Capp, imagePolicy cappv1alpha1.ImagePolicy) error {
+	digestRequired := slices.Contains(imagePolicy.DigestRequiredNamespaces, capp.Namespace)
+	podSpecPath := field.NewPath("spec", "configurationSpec", "template", "spec")
+	podSpec := capp.Spec.ConfigurationSpec.Template.Spec
+
+	var errs field.ErrorList
+	errs = append(errs, validateContainerImages(podSpec.InitContainers, podSpecPath.Child("initContainers"), imagePolicy, digestRequired)...)
+	errs = append(errs, validateContainerImages(podSpec.Containers, podSpecPath.Child("containers"), imagePolicy, digestRequired)...)
+
+	return errs.ToAggregate()
+}
+
+func validateContainerImages(containers []corev1.Container, path *field.Path, imagePolicy cappv1alpha1.ImagePolicy, digestRequired bool) field.ErrorList {
+	var errs field.ErrorList
+	for i, container := range containers {
+		if msg := imagePolicyViolation(container.Image, imagePolicy, digestRequired); msg != "" {
+			errs = append(errs, field.Forbidden(path.Index(i).Child("image"), fmt.Sprintf("image %q %s", container.Image, msg)))
+		}
+	}
+	return errs
+}
+
+// imagePolicyViolation returns a description of the first rule the image breaks, or an empty string.
+func imagePolicyViolation(image string, imagePolicy cappv1alpha1.ImagePolicy, digestRequired bool) string {
+	qualified := qualifyImage(image)
+	if len(imagePolicy.AllowedRegistries) > 0 && !slices.ContainsFunc(imagePolicy.AllowedRegistries, func(prefix string) bool {
+		return hasPathPrefix(qualified, prefix)
+	}) {
+		return fmt.Sprintf("is not from an allowed registry (%s)", strings.Join(imagePolicy.AllowedRegistries, ", "))
+	}
+
+	tag, digest := splitImage(image)
+	if digestRequired && digest == "" {
+		return "must be pinned by digest"
+	}
+
+	if imagePolicy.ForbidLatestTag && digest == "" && (tag == "" || tag == latestTag) {
+		return "must not use the latest tag"
+	}
+
+	return ""
+}
+
+// hasPathPrefix reports whether the qualified image starts with prefix on a path segment boundary,
+// so that a registry host or repository path never matches a longer one it is a prefix of.
+func hasPathPrefix(qualified, prefix string) bool {
+	return strings.HasPrefix(qualified, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+// splitImage returns the tag and digest of an image reference.
+func splitImage(image string) (tag, digest string) {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		tag = name[i+1:]
+	}
+	return tag, digest
+}
+
+// qualifyImage returns the image reference with the implicit Docker Hub registry and namespace made explicit.
+func qualifyImage(image string) string {
+	firstSegment, rest, found := strings.Cut(image, "/")
+	if !found {
+		return defaultRegistry + "/" + defaultNamespace + "/" + image
+	}
+	if firstSegment == defaultRegistry && !strings.Contains(rest, "/") {
+		return defaultRegistry + "/" + defaultNamespace + "/" + rest
+	}
+	if strings.ContainsAny(firstSegment, ".:") || firstSegment == "localhost" {
+		return image
+	}
+	return defaultRegistry + "/" + firstSegment + "/" + rest
+}
@@ -0,0 +1,189 @@
+// Package policy holds the checks a Capp must pass against the limits of its effective CappConfig.
+// They are shared by the admission webhook and the controllers, so that a Capp admitted under one
+// config can be re-checked once the config changes.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/network"
+)
+
+// Violations returns every limit of the given CappConfig spec that the Capp violates.
+// It only covers checks that depend on the config, not on other cluster state.
+func Violations(capp cappv1alpha1.Capp, spec cappv1alpha1.CappConfigSpec) []error {
+	var violations []error
+
+	if err := ValidateScaleSpec(capp, spec.AutoscaleConfig); err != nil {
+		violations = append(violations, err)
+	}
+
+	if err := ValidateScaleToZero(capp, spec.AutoscaleConfig); err != nil {
+		violations = append(violations, err)
+	}
+
+	if err := ValidateDomainName(capp.Spec.RouteSpec.Hostname, spec.AllowedHostnamePatterns); err != nil {
+		violations = append(violations, err)
+	}
+
+	if err := ValidateImagePolicy(capp, spec.ImagePolicy); err != nil {
+		violations = append(violations, err)
+	}
+
+	if err := ValidatePrivilegeEscalation(capp, spec.SecurityConfig); err != nil {
+		violations = append(violations, err)
+	}
+
+	for _, src := range capp.Spec.EventSourcesSpec.Sources {
+		if src.KafkaSourceConfiguration == nil {
+			continue
+		}
+		if err := ValidateKafkaSourceConsumers(src.KafkaSourceConfiguration, spec.MaxKafkaConsumers); err != nil {
+			violations = append(violations, fmt.Errorf("event source %q: %w", src.Name, err))
+		}
+	}
+
+	return violations
+}
+
+// ValidateDomainName makes sure a non-empty hostname is a valid domain name matching at least one of the
+// allowed patterns, and not part of the cluster's domain. Allowed patterns that fail to compile are
+// reported as errors.
+func ValidateDomainName(domainName string, allowedPatterns []cappv1alpha1.HostnamePattern) error {
+	if domainName == "" {
+		return nil
+	}
+	var errs *apis.FieldError
+	err := validation.IsFullyQualifiedDomainName(field.NewPath("name"), domainName)
+	if err != nil {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(
+			"invalid name %q: %s", domainName, err.ToAggregate()), "name"))
+	}
+	matched := false
+	descriptions := make([]string, 0, len(allowedPatterns))
+	for i, hp := range allowedPatterns {
+		re, err := regexp.Compile(hp.Match)
+		if err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid pattern %q: %s", hp.Match, err), fmt.Sprintf("allowedHostnamePatterns[%d].pattern", i)))
+			continue
+		}
+		if hp.Explanation != "" {
+			descriptions = append(descriptions, hp.Explanation)
+		} else {
+			descriptions = append(descriptions, hp.Match)
+		}
+		if !matched && re.MatchString(domainName) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		msg := fmt.Sprintf("invalid name %q: must match one of the allowed patterns", domainName)
+		if len(descriptions) > 0 {
+			msg = fmt.Sprintf("%s (%s)", msg, strings.Join(descriptions, ", "))
+		}
+		errs = errs.Also(apis.ErrGeneric(msg, "name").ViaField("routeSpec").ViaField("hostname"))
+	}
+
+	clusterLocalDomain := network.GetClusterDomainName()
+	if strings.HasSuffix(domainName, "."+clusterLocalDomain) {
+		errs = errs.Also(apis.ErrGeneric(
+			fmt.Sprintf("invalid name %q: must not be a subdomain of cluster local domain %q", domainName, clusterLocalDomain), "name"))
+	}
+	if errs == nil {
+		return nil
+	}
+	return errs
+}
+
+// ValidateScaleSpec makes sure the replica bounds, scale delay and initial scale of the Capp are within the autoscale config limits.
+func ValidateScaleSpec(capp cappv1alpha1.Capp, autoscaleConfig cappv1alpha1.AutoscaleConfig) error {
+	minReplicas := capp.Spec.ScaleSpec.MinReplicas
+	maxReplicas := capp.Spec.ScaleSpec.MaxReplicas
+	scaleDelay := capp.Spec.ScaleSpec.ScaleDelaySeconds
+	initialScale := capp.Spec.ScaleSpec.InitialScale
+
+	if minReplicas != nil && int(*minReplicas) > autoscaleConfig.MinReplicasLimit {
+		return fmt.Errorf("invalid minReplicas %d: must be less than or equal to global min scale %d", *minReplicas, autoscaleConfig.MinReplicasLimit)
+	}
+
+	if maxReplicas != nil && int(*maxReplicas) > autoscaleConfig.MaxReplicasLimit {
+		return fmt.Errorf("invalid maxReplicas %d: must be less than or equal to global max scale %d", *maxReplicas, autoscaleConfig.MaxReplicasLimit)
+	}
+
+	if maxReplicas != nil && minReplicas != nil && *maxReplicas < *minReplicas {
+		return fmt.Errorf("invalid maxReplicas %d: must be greater than or equal to minReplicas %d", *maxReplicas, *minReplicas)
+	}
+
+	if maxReplicas != nil && minReplicas == nil && int(*maxReplicas) < autoscaleConfig.ActivationScale {
+		return fmt.Errorf("invalid maxReplicas %d: must be greater than or equal to activationScale %d",
+			*maxReplicas, autoscaleConfig.ActivationScale)
+	}
+
+	if scaleDelay != nil && int(*scaleDelay) > autoscaleConfig.MaxScaleDelay {
+		return fmt.Errorf("invalid scaleDelaySeconds %d: must be less than or equal to global max scale delay %d", *scaleDelay, autoscaleConfig.MaxScaleDelay)
+	}
+
+	if initialScale != nil && int(*initialScale) > autoscaleConfig.MaxReplicasLimit {
+		return fmt.Errorf("invalid initialScale %d: must be less than or equal to global max scale %d", *initialScale, autoscaleConfig.MaxReplicasLimit)
+	}
+
+	if initialScale != nil && maxReplicas != nil && *initialScale > *maxReplicas {
+		return fmt.Errorf("invalid initialScale %d: must be less than or equal to maxReplicas %d", *initialScale, *maxReplicas)
+	}
+
+	return nil
+}
+
+// ValidateScaleToZero makes sure the scale-to-zero settings of the Capp comply with the autoscale config,
+// in particular that Capps in warm namespaces always keep at least one replica.
+func ValidateScaleToZero(capp cappv1alpha1.Capp, autoscaleConfig cappv1alpha1.AutoscaleConfig) error {
+	scaleSpec := capp.Spec.ScaleSpec
+	warmNamespace := slices.Contains(autoscaleConfig.WarmNamespaces, capp.Namespace)
+
+	if warmNamespace && scaleSpec.AllowScaleToZero != nil && *scaleSpec.AllowScaleToZero {
+		return fmt.Errorf("invalid allowScaleToZero: namespace %q requires Capps to keep at least one warm replica", capp.Namespace)
+	}
+
+	if rmanagers.ScaleToZeroAllowed(capp, autoscaleConfig) {
+		retention := scaleSpec.ScaleToZeroRetentionSeconds
+		if retention != nil && int(*retention) > autoscaleConfig.MaxScaleToZeroRetention {
+			return fmt.Errorf("invalid scaleToZeroRetentionSeconds %d: must be less than or equal to global max scale to zero retention %d",
+				*retention, autoscaleConfig.MaxScaleToZeroRetention)
+		}
+		return nil
+	}
+
+	if scaleSpec.MinReplicas != nil && *scaleSpec.MinReplicas < 1 {
+		return fmt.Errorf("invalid minReplicas %d: must be at least 1 when scaling to zero is not allowed", *scaleSpec.MinReplicas)
+	}
+
+	if scaleSpec.InitialScale != nil && *scaleSpec.InitialScale < 1 {
+		return fmt.Errorf("invalid initialScale %d: must be at least 1 when scaling to zero is not allowed", *scaleSpec.InitialScale)
+	}
+
+	if scaleSpec.ScaleToZeroRetentionSeconds != nil {
+		return fmt.Errorf("invalid scaleToZeroRetentionSeconds: cannot be set when scaling to zero is not allowed")
+	}
+
+	return nil
+}
+
+// ValidateKafkaSourceConsumers makes sure a KafkaSource entry does not request more consumers than allowed.
+func ValidateKafkaSourceConsumers(cfg *cappv1alpha1.KafkaSourceConfiguration, maxKafkaConsumers int32) error {
+	if cfg.Consumers == nil {
+		return nil
+	}
+	if *cfg.Consumers > maxKafkaConsumers {
+		return fmt.Errorf("invalid consumers %d: must be less than or equal to global max kafka consumers %d", *cfg.Consumers, maxKafkaConsumers)
+	}
+	return nil
+}
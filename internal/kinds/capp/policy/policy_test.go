@@ -0,0 +1,643 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	cappName = "test-capp"
+	nsName   = "test-ns"
+
+	allowedHostnamePattern      = `.*\.example\.com`
+	nonMatchingHostname         = "myapp.other.com"
+	errMustMatchAllowedPatterns = "must match one of the allowed patterns"
+)
+
+func TestViolations(t *testing.T) {
+	spec := cappv1alpha1.CappConfigSpec{
+		AutoscaleConfig: cappv1alpha1.AutoscaleConfig{
+			MinReplicasLimit: 5,
+			MaxReplicasLimit: 10,
+			MaxScaleDelay:    100,
+		},
+		AllowedHostnamePatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.example\.com`}},
+		MaxKafkaConsumers:       2,
+	}
+
+	t.Run("returns no violations for a compliant Capp", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: nsName},
+			Spec: cappv1alpha1.CappSpec{
+				ScaleSpec: cappv1alpha1.ScaleSpec{MinReplicas: ptr.To(int32(2)), MaxReplicas: ptr.To(int32(10))},
+				RouteSpec: cappv1alpha1.RouteSpec{Hostname: "app.example.com"},
+			},
+		}
+		require.Empty(t, Violations(capp, spec))
+	})
+
+	t.Run("returns every violated limit", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: nsName},
+			Spec: cappv1alpha1.CappSpec{
+				ScaleSpec: cappv1alpha1.ScaleSpec{MinReplicas: ptr.To(int32(6))},
+				RouteSpec: cappv1alpha1.RouteSpec{Hostname: "app.other.com"},
+				EventSourcesSpec: cappv1alpha1.EventSourcesSpec{Sources: []cappv1alpha1.SourceConfiguration{{
+					Name:                     "orders",
+					KafkaSourceConfiguration: &cappv1alpha1.KafkaSourceConfiguration{Consumers: ptr.To(int32(3))},
+				}}},
+			},
+		}
+
+		violations := Violations(capp, spec)
+		require.Len(t, violations, 3)
+		assert.Contains(t, violations[0].Error(), "minReplicas")
+		assert.Contains(t, violations[1].Error(), "app.other.com")
+		assert.Contains(t, violations[2].Error(), "orders")
+	})
+}
+
+func TestValidateDomainName(t *testing.T) {
+	tests := []struct {
+		name            string
+		domainName      string
+		allowedPatterns []cappv1alpha1.HostnamePattern
+		wantErr         bool
+		errContains     string
+	}{
+		{
+			name:            "Empty hostname",
+			domainName:      "",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{},
+			wantErr:         false,
+		},
+		{
+			name:            "Valid domain matching specific pattern",
+			domainName:      "myapp.example.com",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
+			wantErr:         false,
+		},
+		{
+			name:            "Valid domain matching wild card",
+			domainName:      "myapp.any.com",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
+			wantErr:         false,
+		},
+		{
+			name:            "Invalid domain not matching pattern",
+			domainName:      nonMatchingHostname,
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
+			wantErr:         true,
+			errContains:     errMustMatchAllowedPatterns,
+		},
+		{
+			name:            "Empty allowed patterns (deny all)",
+			domainName:      "myapp.example.com",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{},
+			wantErr:         true,
+			errContains:     errMustMatchAllowedPatterns,
+		},
+		{
+			name:            "Multiple patterns, one match",
+			domainName:      "myapp.org",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.com`}, {Match: `.*\.org`}},
+			wantErr:         false,
+		},
+		{
+			name:            "Multiple patterns, no match",
+			domainName:      "myapp.net",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.com`}, {Match: `.*\.org`}},
+			wantErr:         true,
+			errContains:     errMustMatchAllowedPatterns,
+		},
+		{
+			name:            "Invalid FQDN syntax",
+			domainName:      "-invalid-start",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
+			wantErr:         true,
+		},
+		{
+			name:            "Invalid hostname with leading dots rejected as FQDN",
+			domainName:      "...aaa.a....",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
+			wantErr:         true,
+		},
+		{
+			name:            "Invalid hostname with underscore rejected as FQDN under wildcard patterns",
+			domainName:      "invalid_domain!",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
+			wantErr:         true,
+		},
+		{
+			name:            "Invalid pattern reported even when another pattern matches",
+			domainName:      "myapp.example.com",
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: "("}, {Match: allowedHostnamePattern}},
+			wantErr:         true,
+			errContains:     "invalid pattern",
+		},
+		{
+			name:            "Explanation appears in error message",
+			domainName:      nonMatchingHostname,
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern, Explanation: "subdomains of example.com only"}},
+			wantErr:         true,
+			errContains:     "subdomains of example.com only",
+		},
+		{
+			name:            "Raw pattern shown when explanation absent",
+			domainName:      nonMatchingHostname,
+			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
+			wantErr:         true,
+			errContains:     allowedHostnamePattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDomainName(tt.domainName, tt.allowedPatterns)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateKafkaSourceConsumers(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             *cappv1alpha1.KafkaSourceConfiguration
+		maxConsumers    int32
+		wantErrContains []string
+	}{
+		{
+			name:         "allows consumers within capacity",
+			cfg:          &cappv1alpha1.KafkaSourceConfiguration{Consumers: ptr.To(int32(3))},
+			maxConsumers: 5,
+		},
+		{
+			name:         "rejects consumers above capacity",
+			cfg:          &cappv1alpha1.KafkaSourceConfiguration{Consumers: ptr.To(int32(6))},
+			maxConsumers: 5,
+			wantErrContains: []string{
+				"consumers",
+				"max kafka consumers",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateKafkaSourceConsumers(tc.cfg, tc.maxConsumers)
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, s := range tc.wantErrContains {
+				assert.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestValidateScaleSpec(t *testing.T) {
+	const maxReplicasErrMsg = "maxReplicas"
+
+	tests := []struct {
+		name              string
+		minReplicas       *int32
+		maxReplicas       *int32
+		scaleDelaySeconds *int32
+		initialScale      *int32
+		autoscaleConfig   cappv1alpha1.AutoscaleConfig
+		wantErrContains   []string
+	}{
+		{
+			name:        "allows when minReplicas is at the limit",
+			minReplicas: ptr.To(int32(10)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+			},
+		},
+		{
+			name:              "allows when scaleDelaySeconds is at the limit",
+			scaleDelaySeconds: ptr.To(int32(100)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+			},
+		},
+		{
+			name:        "rejects when minReplicas exceeds the limit",
+			minReplicas: ptr.To(int32(11)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+			},
+			wantErrContains: []string{"minReplicas"},
+		},
+		{
+			name:              "rejects when scaleDelaySeconds exceeds the limit",
+			scaleDelaySeconds: ptr.To(int32(101)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+			},
+			wantErrContains: []string{"scaleDelaySeconds"},
+		},
+		{
+			name:        "allows when maxReplicas is at the limit",
+			maxReplicas: ptr.To(int32(10)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+		},
+		{
+			name:        "rejects when maxReplicas exceeds the limit",
+			maxReplicas: ptr.To(int32(11)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+			wantErrContains: []string{maxReplicasErrMsg},
+		},
+		{
+			name:        "rejects when maxReplicas is less than minReplicas",
+			minReplicas: ptr.To(int32(5)),
+			maxReplicas: ptr.To(int32(3)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+			wantErrContains: []string{maxReplicasErrMsg, "minReplicas"},
+		},
+		{
+			name:        "allows when maxReplicas equals minReplicas",
+			minReplicas: ptr.To(int32(3)),
+			maxReplicas: ptr.To(int32(3)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+		},
+		{
+			name:        "rejects when maxReplicas is less than activationScale and minReplicas is zero",
+			maxReplicas: ptr.To(int32(1)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+				ActivationScale:  2,
+			},
+			wantErrContains: []string{maxReplicasErrMsg, "activationScale"},
+		},
+		{
+			name:        "allows when maxReplicas equals activationScale and minReplicas is zero",
+			maxReplicas: ptr.To(int32(2)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+				ActivationScale:  2,
+			},
+		},
+		{
+			name:         "rejects when initialScale exceeds the limit",
+			initialScale: ptr.To(int32(11)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+			wantErrContains: []string{"initialScale"},
+		},
+		{
+			name:         "rejects when initialScale is greater than maxReplicas",
+			initialScale: ptr.To(int32(5)),
+			maxReplicas:  ptr.To(int32(4)),
+			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 10,
+				MaxScaleDelay:    100,
+				MaxReplicasLimit: 10,
+			},
+			wantErrContains: []string{"initialScale", maxReplicasErrMsg},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{
+				Spec: cappv1alpha1.CappSpec{
+					ScaleSpec: cappv1alpha1.ScaleSpec{
+						MinReplicas:       tc.minReplicas,
+						MaxReplicas:       tc.maxReplicas,
+						ScaleDelaySeconds: tc.scaleDelaySeconds,
+						InitialScale:      tc.initialScale,
+					},
+				},
+			}
+
+			err := ValidateScaleSpec(capp, tc.autoscaleConfig)
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, s := range tc.wantErrContains {
+				assert.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestValidateScaleToZero(t *testing.T) {
+	const warmNamespace = "production"
+
+	autoscaleConfig := cappv1alpha1.AutoscaleConfig{
+		MaxScaleToZeroRetention: 600,
+		WarmNamespaces:          []string{warmNamespace},
+	}
+
+	tests := []struct {
+		name            string
+		namespace       string
+		scaleSpec       cappv1alpha1.ScaleSpec
+		wantErrContains []string
+	}{
+		{
+			name:      "allows retention at the limit",
+			namespace: nsName,
+			scaleSpec: cappv1alpha1.ScaleSpec{ScaleToZeroRetentionSeconds: ptr.To(int32(600))},
+		},
+		{
+			name:            "rejects retention above the limit",
+			namespace:       nsName,
+			scaleSpec:       cappv1alpha1.ScaleSpec{ScaleToZeroRetentionSeconds: ptr.To(int32(601))},
+			wantErrContains: []string{"scaleToZeroRetentionSeconds"},
+		},
+		{
+			name:      "allows a Capp in a warm namespace that does not opt in to scale to zero",
+			namespace: warmNamespace,
+			scaleSpec: cappv1alpha1.ScaleSpec{InitialScale: ptr.To(int32(2))},
+		},
+		{
+			name:            "rejects allowScaleToZero in a warm namespace",
+			namespace:       warmNamespace,
+			scaleSpec:       cappv1alpha1.ScaleSpec{AllowScaleToZero: ptr.To(true)},
+			wantErrContains: []string{"allowScaleToZero", warmNamespace},
+		},
+		{
+			name:            "rejects zero minReplicas when scale to zero is disabled",
+			namespace:       nsName,
+			scaleSpec:       cappv1alpha1.ScaleSpec{AllowScaleToZero: ptr.To(false), MinReplicas: ptr.To(int32(0))},
+			wantErrContains: []string{"minReplicas"},
+		},
+		{
+			name:            "rejects zero initialScale in a warm namespace",
+			namespace:       warmNamespace,
+			scaleSpec:       cappv1alpha1.ScaleSpec{InitialScale: ptr.To(int32(0))},
+			wantErrContains: []string{"initialScale"},
+		},
+		{
+			name:            "rejects retention when scale to zero is disabled",
+			namespace:       nsName,
+			scaleSpec:       cappv1alpha1.ScaleSpec{AllowScaleToZero: ptr.To(false), ScaleToZeroRetentionSeconds: ptr.To(int32(10))},
+			wantErrContains: []string{"scaleToZeroRetentionSeconds"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{
+				ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: tc.namespace},
+				Spec:       cappv1alpha1.CappSpec{ScaleSpec: tc.scaleSpec},
+			}
+
+			err := ValidateScaleToZero(capp, autoscaleConfig)
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, s := range tc.wantErrContains {
+				assert.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestValidateImagePolicy(t *testing.T) {
+	newImageCapp := func(namespace string, initImages, images []string) cappv1alpha1.Capp {
+		capp := cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: namespace}}
+		for i, image := range initImages {
+			capp.Spec.ConfigurationSpec.Template.Spec.InitContainers = append(capp.Spec.ConfigurationSpec.Template.Spec.InitContainers,
+				corev1.Container{Name: fmt.Sprintf("init-%d", i), Image: image})
+		}
+		for i, image := range images {
+			capp.Spec.ConfigurationSpec.Template.Spec.Containers = append(capp.Spec.ConfigurationSpec.Template.Spec.Containers,
+				corev1.Container{Name: fmt.Sprintf("app-%d", i), Image: image})
+		}
+		return capp
+	}
+
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name        string
+		capp        cappv1alpha1.Capp
+		imagePolicy cappv1alpha1.ImagePolicy
+		expectErrs  []string
+	}{
+		{
+			name:        "allows any image with an empty policy",
+			capp:        newImageCapp(nsName, nil, []string{"nginx"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{},
+		},
+		{
+			name:        "allows images from an allowed registry",
+			capp:        newImageCapp(nsName, []string{"ghcr.io/dana-team/init:v1"}, []string{"ghcr.io/dana-team/app:v1"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"ghcr.io/dana-team/"}},
+		},
+		{
+			name:        "matches Docker Hub images by their qualified name",
+			capp:        newImageCapp(nsName, nil, []string{"nginx:1.27", "bitnami/redis:7"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"docker.io/library/", "docker.io/bitnami/"}},
+		},
+		{
+			name:        "matches Docker Hub images with an explicit registry by their qualified name",
+			capp:        newImageCapp(nsName, nil, []string{"docker.io/nginx:1.27", "docker.io/bitnami/redis:7"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"docker.io/library/", "docker.io/bitnami/"}},
+		},
+		{
+			name:        "lists every image from a disallowed registry",
+			capp:        newImageCapp(nsName, []string{"quay.io/init:v1"}, []string{"ghcr.io/dana-team/app:v1", "nginx:1.27"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"ghcr.io/dana-team/"}},
+			expectErrs: []string{
+				"spec.configurationSpec.template.spec.initContainers[0].image",
+				"spec.configurationSpec.template.spec.containers[1].image",
+			},
+		},
+		{
+			name:        "allows images from a registry host listed without a trailing slash",
+			capp:        newImageCapp(nsName, nil, []string{"registry.corp.com/team/app:v1"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"registry.corp.com"}},
+		},
+		{
+			name:        "rejects registry hosts extending an allowed one",
+			capp:        newImageCapp(nsName, nil, []string{"registry.corp.com.evil.io/x:v1", "registry.corp.comx/app:v1"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"registry.corp.com"}},
+			expectErrs: []string{
+				"spec.configurationSpec.template.spec.containers[0].image",
+				"spec.configurationSpec.template.spec.containers[1].image",
+			},
+		},
+		{
+			name:        "rejects repository paths extending an allowed one",
+			capp:        newImageCapp(nsName, nil, []string{"ghcr.io/dana-team-evil/app:v1"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{AllowedRegistries: []string{"ghcr.io/dana-team"}},
+			expectErrs:  []string{"spec.configurationSpec.template.spec.containers[0].image"},
+		},
+		{
+			name:        "forbids explicit and implicit latest tags",
+			capp:        newImageCapp(nsName, []string{"busybox"}, []string{"nginx:latest", "registry.local:5000/app:v1"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{ForbidLatestTag: true},
+			expectErrs: []string{
+				"spec.configurationSpec.template.spec.initContainers[0].image",
+				"spec.configurationSpec.template.spec.containers[0].image",
+			},
+		},
+		{
+			name:        "allows untagged images pinned by digest when latest is forbidden",
+			capp:        newImageCapp(nsName, nil, []string{"nginx@" + digest}),
+			imagePolicy: cappv1alpha1.ImagePolicy{ForbidLatestTag: true},
+		},
+		{
+			name:        "requires digests in selected namespaces",
+			capp:        newImageCapp(nsName, nil, []string{"nginx:1.27", "nginx:1.27@" + digest}),
+			imagePolicy: cappv1alpha1.ImagePolicy{DigestRequiredNamespaces: []string{nsName}},
+			expectErrs:  []string{"spec.configurationSpec.template.spec.containers[0].image"},
+		},
+		{
+			name:        "does not require digests in other namespaces",
+			capp:        newImageCapp("other-ns", nil, []string{"nginx:1.27"}),
+			imagePolicy: cappv1alpha1.ImagePolicy{DigestRequiredNamespaces: []string{nsName}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateImagePolicy(tc.capp, tc.imagePolicy)
+			if len(tc.expectErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expectErr := range tc.expectErrs {
+				assert.Contains(t, err.Error(), expectErr)
+			}
+			assert.Equal(t, len(tc.expectErrs), strings.Count(err.Error(), ".image"))
+		})
+	}
+}
+
+func TestQualifyImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx", want: "docker.io/library/nginx"},
+		{image: "nginx:1.27", want: "docker.io/library/nginx:1.27"},
+		{image: "docker.io/nginx", want: "docker.io/library/nginx"},
+		{image: "docker.io/library/nginx", want: "docker.io/library/nginx"},
+		{image: "bitnami/redis", want: "docker.io/bitnami/redis"},
+		{image: "docker.io/bitnami/redis", want: "docker.io/bitnami/redis"},
+		{image: "localhost/app", want: "localhost/app"},
+		{image: "registry.local:5000/app:v1", want: "registry.local:5000/app:v1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			assert.Equal(t, tc.want, qualifyImage(tc.image))
+		})
+	}
+}
+
+func TestValidatePrivilegeEscalation(t *testing.T) {
+	newSecurityCapp := func(namespace string, initSecurityContext, securityContext *corev1.SecurityContext) cappv1alpha1.Capp {
+		capp := cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: namespace}}
+		capp.Spec.ConfigurationSpec.Template.Spec.InitContainers = []corev1.Container{{Name: "init", SecurityContext: initSecurityContext}}
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: "app", SecurityContext: securityContext}}
+		return capp
+	}
+	escalating := &corev1.SecurityContext{Privileged: ptr.To(true), AllowPrivilegeEscalation: ptr.To(true)}
+	forbidding := cappv1alpha1.SecurityConfig{ForbidPrivilegeEscalation: true, ExemptNamespaces: []string{"exempt-ns"}}
+
+	tests := []struct {
+		name           string
+		capp           cappv1alpha1.Capp
+		securityConfig cappv1alpha1.SecurityConfig
+		expectErrs     []string
+	}{
+		{
+			name:           "allows privilege escalation when not forbidden",
+			capp:           newSecurityCapp(nsName, nil, escalating),
+			securityConfig: cappv1alpha1.SecurityConfig{},
+		},
+		{
+			name:           "allows containers without privileges",
+			capp:           newSecurityCapp(nsName, nil, &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(false)}),
+			securityConfig: forbidding,
+		},
+		{
+			name:           "lists every privileged field",
+			capp:           newSecurityCapp(nsName, &corev1.SecurityContext{AllowPrivilegeEscalation: ptr.To(true)}, escalating),
+			securityConfig: forbidding,
+			expectErrs: []string{
+				"spec.configurationSpec.template.spec.initContainers[0].securityContext.allowPrivilegeEscalation",
+				"spec.configurationSpec.template.spec.containers[0].securityContext.privileged",
+				"spec.configurationSpec.template.spec.containers[0].securityContext.allowPrivilegeEscalation",
+			},
+		},
+		{
+			name:           "allows privilege escalation in exempt namespaces",
+			capp:           newSecurityCapp("exempt-ns", nil, escalating),
+			securityConfig: forbidding,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePrivilegeEscalation(tc.capp, tc.securityConfig)
+			if len(tc.expectErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expectErr := range tc.expectErrs {
+				assert.Contains(t, err.Error(), expectErr)
+			}
+			assert.Equal(t, len(tc.expectErrs), strings.Count(err.Error(), ".securityContext."))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package policy
+
+import (
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidatePrivilegeEscalation makes sure no container or init container of the Capp is privileged or
+// allows privilege escalation when the security config forbids it, and returns an aggregate of
+// field-pathed errors, one per offending field.
+func ValidatePrivilegeEscalation(capp cappv1alpha1.Capp, securityConfig cappv1alpha1.SecurityConfig) error {
+	if !securityConfig.ForbidPrivilegeEscalation || rmanagers.IsSecurityExempt(capp.Namespace, securityConfig) {
+		return nil
+	}
+	podSpecPath := field.NewPath("spec", "configurationSpec", "template", "spec")
+	podSpec := capp.Spec.ConfigurationSpec.Template.Spec
+
+	var errs field.ErrorList
+	errs = append(errs, validateContainerPrivileges(podSpec.InitContainers, podSpecPath.Child("initContainers"))...)
+	errs = append(errs, validateContainerPrivileges(podSpec.Containers, podSpecPath.Child("containers"))...)
+
+	return errs.ToAggregate()
+}
+
+func validateContainerPrivileges(containers []corev1.Container, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, container := range containers {
+		securityContext := container.SecurityContext
+		if securityContext == nil {
+			continue
+		}
+		securityContextPath := path.Index(i).Child("securityContext")
+		if securityContext.Privileged != nil && *securityContext.Privileged {
+			errs = append(errs, field.Forbidden(securityContextPath.Child("privileged"), "privileged containers are not allowed"))
+		}
+		if securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation {
+			errs = append(errs, field.Forbidden(securityContextPath.Child("allowPrivilegeEscalation"), "privilege escalation is not allowed"))
+		}
+	}
+	return errs
+}
@@ -2,14 +2,24 @@ package resourceclient
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/dana-team/container-app-operator/internal/metrics"
+	"github.com/dana-team/container-app-operator/internal/tracing"
 )
 
+// FieldManager is the server-side apply field manager owning the fields the operator sets on child resources.
+const FieldManager = "container-app-operator"
+
 type ResourceManagerClient struct {
 	K8sClient client.Client
 	Log       logr.Logger
@@ -18,7 +28,12 @@ type ResourceManagerClient struct {
 // CreateResource creates a resource.
 func (r ResourceManagerClient) CreateResource(ctx context.Context, resource client.Object) error {
 	r.Log.Info("kubernetes API write create", cappmeta.ObjectIdentityKeyVals(resource)...)
-	if err := r.K8sClient.Create(ctx, resource); err != nil {
+	kind := r.kindOf(resource)
+	ctx, span := tracing.Start(ctx, "ResourceManagerClient.Create", tracing.ObjectAttributes(kind, resource.GetNamespace(), resource.GetName())...)
+	err := r.K8sClient.Create(ctx, resource)
+	tracing.End(span, err)
+	metrics.RecordChildResourceOperation(kind, metrics.OperationCreate, err)
+	if err != nil {
 		return fmt.Errorf("failed to create resource %s %s: %w", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName(), err)
 	}
 	return nil
@@ -27,17 +42,203 @@ func (r ResourceManagerClient) CreateResource(ctx context.Context, resource clie
 // UpdateResource updates a resource.
 func (r ResourceManagerClient) UpdateResource(ctx context.Context, resource client.Object) error {
 	r.Log.Info("kubernetes API write update", cappmeta.ObjectIdentityKeyVals(resource)...)
-	if err := r.K8sClient.Update(ctx, resource); err != nil {
+	kind := r.kindOf(resource)
+	ctx, span := tracing.Start(ctx, "ResourceManagerClient.Update", tracing.ObjectAttributes(kind, resource.GetNamespace(), resource.GetName())...)
+	err := r.K8sClient.Update(ctx, resource)
+	tracing.End(span, err)
+	metrics.RecordChildResourceOperation(kind, metrics.OperationUpdate, err)
+	if err != nil {
 		return fmt.Errorf("failed to update %s %s: %w", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName(), err)
 	}
 	return nil
 }
 
+// ApplyResource server-side applies a resource as FieldManager, creating it if it does not exist.
+// Only the fields set on resource are owned by the operator; fields set by other managers are left
+// untouched, and fields the operator previously applied but no longer sets are removed.
+// On success, resource is updated with the object returned by the API server.
+func (r ResourceManagerClient) ApplyResource(ctx context.Context, resource client.Object) error {
+	r.Log.Info("kubernetes API write apply", cappmeta.ObjectIdentityKeyVals(resource)...)
+	kind := r.kindOf(resource)
+	ctx, span := tracing.Start(ctx, "ResourceManagerClient.Apply", tracing.ObjectAttributes(kind, resource.GetNamespace(), resource.GetName())...)
+	err := r.apply(ctx, resource)
+	tracing.End(span, err)
+	metrics.RecordChildResourceOperation(kind, metrics.OperationApply, err)
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", kind, resource.GetName(), err)
+	}
+	return nil
+}
+
+func (r ResourceManagerClient) apply(ctx context.Context, resource client.Object) error {
+	u, err := r.applyConfiguration(resource)
+	if err != nil {
+		return err
+	}
+	if err := r.K8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, resource)
+}
+
+// applyConfiguration converts resource to the unstructured apply configuration sent to the API server.
+// It holds only the fields set on resource: server-populated metadata, the status and unset fields are
+// dropped so the operator does not claim fields owned by other managers.
+func (r ResourceManagerClient) applyConfiguration(resource client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := r.K8sClient.GroupVersionKindFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(resource)
+	if err != nil {
+		return nil, err
+	}
+	pruneUnset(reflect.ValueOf(resource), content)
+	pruneNulls(content)
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "uid"} {
+			delete(metadata, field)
+		}
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+// pruneUnset recursively removes from content, the unstructured form of value, the fields value leaves
+// unset. Fields that are not pointers, maps, slices or interfaces cannot tell a zero value apart from an
+// unset one, so they are unset when they hold their zero value; converting them would otherwise make a
+// zero the operator never set, such as the port of a probe, override the value of another manager.
+func pruneUnset(value reflect.Value, content any) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if fields, ok := content.(map[string]any); ok && !encodesItself(value.Type()) {
+			pruneUnsetFields(value, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := content.([]any)
+		if !ok {
+			return
+		}
+		for i := range min(value.Len(), len(items)) {
+			pruneUnset(value.Index(i), items[i])
+		}
+	case reflect.Map:
+		entries, ok := content.(map[string]any)
+		if !ok || value.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for iter := value.MapRange(); iter.Next(); {
+			pruneUnset(iter.Value(), entries[iter.Key().String()])
+		}
+	}
+}
+
+// pruneUnsetFields removes from fields the fields of the struct value it leaves unset, including those
+// of its inlined structs.
+func pruneUnsetFields(value reflect.Value, fields map[string]any) {
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() && !field.Anonymous || name == "-" {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if field.Anonymous && name == "" {
+			if fieldValue.Kind() == reflect.Pointer {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct && !encodesItself(fieldValue.Type()) {
+				pruneUnsetFields(fieldValue, fields)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		item, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if isUnsetLeaf(fieldValue) {
+			delete(fields, name)
+			continue
+		}
+		pruneUnset(fieldValue, item)
+	}
+}
+
+// isUnsetLeaf reports whether value is a scalar, or a value with its own encoding such as a Quantity
+// or an IntOrString, holding its zero value.
+func isUnsetLeaf(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return false
+	case reflect.Struct:
+		return encodesItself(value.Type()) && value.IsZero()
+	default:
+		return value.IsZero()
+	}
+}
+
+func encodesItself(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)
+}
+
+// pruneNulls recursively removes null values, which server-side apply would otherwise treat as field removals.
+func pruneNulls(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			pruneNulls(item)
+		}
+	case []any:
+		for _, item := range v {
+			pruneNulls(item)
+		}
+	}
+}
+
 // DeleteResource deletes a resource.
 func (r ResourceManagerClient) DeleteResource(ctx context.Context, resource client.Object) error {
 	r.Log.Info("kubernetes API write delete", cappmeta.ObjectIdentityKeyVals(resource)...)
-	if err := r.K8sClient.Delete(ctx, resource); err != nil {
+	kind := r.kindOf(resource)
+	ctx, span := tracing.Start(ctx, "ResourceManagerClient.Delete", tracing.ObjectAttributes(kind, resource.GetNamespace(), resource.GetName())...)
+	err := r.K8sClient.Delete(ctx, resource)
+	tracing.End(span, err)
+	metrics.RecordChildResourceOperation(kind, metrics.OperationDelete, err)
+	if err != nil {
 		return fmt.Errorf("failed to delete %s %s: %w", resource.GetObjectKind().GroupVersionKind().Kind, resource.GetName(), err)
 	}
 	return nil
 }
+
+// kindOf returns the kind of the resource, resolving it from the scheme when the object has no type meta.
+func (r ResourceManagerClient) kindOf(resource client.Object) string {
+	if kind := resource.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	gvk, err := r.K8sClient.GroupVersionKindFor(resource)
+	if err != nil {
+		return ""
+	}
+	return gvk.Kind
+}
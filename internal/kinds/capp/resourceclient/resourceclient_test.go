@@ -0,0 +1,135 @@
+package resourceclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	podName       = "my-pod"
+	podNamespace  = "my-ns"
+	containerName = "app"
+	probePath     = "/healthz"
+	otherManager  = "defaulter"
+)
+
+// schemeClient resolves the kinds of objects from its own scheme.
+type schemeClient struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (c schemeClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return apiutil.GVKForObject(obj, c.scheme)
+}
+
+// newClient returns a client backed by a fake client that does not know Pods. Like the API server, the
+// fake client then stores applied Pods as they were applied, instead of converting them to their Go type,
+// which would set the fields they leave unset to zero values.
+func newClient() ResourceManagerClient {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	return ResourceManagerClient{
+		K8sClient: schemeClient{
+			Client: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithReturnManagedFields().Build(),
+			scheme: scheme,
+		},
+		Log: logr.Discard(),
+	}
+}
+
+// newPod returns a Pod whose readiness probe leaves its port unset.
+func newPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: podNamespace},
+		Spec: corev1.PodSpec{
+			EnableServiceLinks: new(bool),
+			Containers: []corev1.Container{{
+				Name:  containerName,
+				Image: "example.com/app:v1",
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: probePath}},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "scratch",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	u, err := newClient().applyConfiguration(newPod())
+	require.NoError(t, err)
+
+	require.Equal(t, "Pod", u.GetKind())
+	require.NotContains(t, u.Object, "status")
+	require.NotContains(t, u.Object["metadata"], "creationTimestamp")
+
+	container := u.Object["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	httpGet := container["readinessProbe"].(map[string]any)["httpGet"].(map[string]any)
+	require.Equal(t, map[string]any{"path": probePath}, httpGet, "the unset port should be dropped")
+
+	enableServiceLinks, found, err := unstructured.NestedBool(u.Object, "spec", "enableServiceLinks")
+	require.NoError(t, err)
+	require.True(t, found, "a pointer set to false should be kept")
+	require.False(t, enableServiceLinks)
+
+	volume := u.Object["spec"].(map[string]any)["volumes"].([]any)[0].(map[string]any)
+	require.Equal(t, map[string]any{}, volume["emptyDir"], "a pointer to an empty struct should be kept")
+}
+
+func TestApplyResource(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps the owner of a field defaulted by another manager", func(t *testing.T) {
+		rc := newClient()
+		defaulted := newPod()
+		defaulted.Spec.Containers[0].ReadinessProbe.HTTPGet.Port = intstr.FromInt32(8080)
+		u, err := rc.applyConfiguration(defaulted)
+		require.NoError(t, err)
+		require.NoError(t, rc.K8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), client.FieldOwner(otherManager)))
+
+		require.NoError(t, rc.ApplyResource(ctx, newPod()))
+
+		pod := &unstructured.Unstructured{}
+		pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+		require.NoError(t, rc.K8sClient.Get(ctx, types.NamespacedName{Name: podName, Namespace: podNamespace}, pod))
+		containers, _, err := unstructured.NestedSlice(pod.Object, "spec", "containers")
+		require.NoError(t, err)
+		port, _, err := unstructured.NestedInt64(containers[0].(map[string]any), "readinessProbe", "httpGet", "port")
+		require.NoError(t, err)
+		require.Equal(t, int64(8080), port)
+
+		owners := map[string]bool{}
+		for _, entry := range pod.GetManagedFields() {
+			owners[entry.Manager] = entry.FieldsV1 != nil && strings.Contains(string(entry.FieldsV1.Raw), `"f:port"`)
+		}
+		require.Contains(t, owners, FieldManager)
+		require.False(t, owners[FieldManager], "the operator should not own the port")
+		require.True(t, owners[otherManager], "the other manager should keep owning the port")
+	})
+
+	t.Run("updates the resource with the applied object", func(t *testing.T) {
+		rc := newClient()
+		pod := newPod()
+		require.NoError(t, rc.ApplyResource(context.Background(), pod))
+		require.NotEmpty(t, pod.ResourceVersion)
+	})
+}
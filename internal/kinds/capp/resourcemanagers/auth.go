@@ -0,0 +1,94 @@
+package resourcemanagers
+
+import (
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AuthProxyContainerName is the name of the oauth2-proxy sidecar injected into Capps requiring a login.
+	AuthProxyContainerName = "oauth2-proxy"
+
+	// AuthClientIDKey, AuthClientSecretKey and AuthCookieSecretKey are the keys of the client Secret of
+	// the OIDC login of a Capp.
+	AuthClientIDKey     = "client-id"
+	AuthClientSecretKey = "client-secret"
+	AuthCookieSecretKey = "cookie-secret"
+
+	defaultAuthProxyImage = "quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"
+	authProxyPort         = 4180
+	defaultUpstreamPort   = 8080
+)
+
+// AuthSecretKeys are the keys the client Secret of the OIDC login of a Capp must hold.
+var AuthSecretKeys = []string{AuthClientIDKey, AuthClientSecretKey, AuthCookieSecretKey}
+
+// addAuthProxy injects into podSpec the oauth2-proxy sidecar enforcing the OIDC login of auth. The
+// sidecar becomes the container Knative routes requests to, and forwards them to the port of the
+// container that received them until now. That container becomes a sidecar, which Knative does not
+// allow to declare probes, so Capps requiring a login are admitted without them.
+func addAuthProxy(podSpec *corev1.PodSpec, auth cappv1alpha1.RouteAuth, authConfig cappv1alpha1.AuthConfig) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	upstream := &podSpec.Containers[servingContainerIndex(podSpec.Containers)]
+	upstreamPort := int32(defaultUpstreamPort)
+	if len(upstream.Ports) > 0 {
+		upstreamPort = upstream.Ports[0].ContainerPort
+	}
+	upstream.Ports = nil
+
+	image := authConfig.ProxyImage
+	if image == "" {
+		image = defaultAuthProxyImage
+	}
+	args := []string{
+		"--provider=oidc",
+		"--oidc-issuer-url=" + auth.IssuerURL,
+		fmt.Sprintf("--http-address=0.0.0.0:%d", authProxyPort),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d", upstreamPort),
+		"--email-domain=*",
+		"--reverse-proxy=true",
+		"--skip-provider-button=true",
+	}
+	for _, group := range auth.AllowedGroups {
+		args = append(args, "--allowed-group="+group)
+	}
+
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:  AuthProxyContainerName,
+		Image: image,
+		Args:  args,
+		Env: []corev1.EnvVar{
+			authSecretEnv("OAUTH2_PROXY_CLIENT_ID", auth.ClientSecret, AuthClientIDKey),
+			authSecretEnv("OAUTH2_PROXY_CLIENT_SECRET", auth.ClientSecret, AuthClientSecretKey),
+			authSecretEnv("OAUTH2_PROXY_COOKIE_SECRET", auth.ClientSecret, AuthCookieSecretKey),
+		},
+		Ports: []corev1.ContainerPort{{ContainerPort: authProxyPort}},
+	})
+}
+
+// servingContainerIndex returns the index of the container Knative routes requests to: the one
+// declaring a port, or the first one if none does.
+func servingContainerIndex(containers []corev1.Container) int {
+	for i, container := range containers {
+		if len(container.Ports) > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func authSecretEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
@@ -0,0 +1,176 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetEffectiveCappConfig returns the CappConfig in effect for Capps in the given namespace: the global
+// CappConfig with every matching CappConfigOverride merged over it. It also returns the names of the
+// applied overrides, in the order they were merged.
+func GetEffectiveCappConfig(ctx context.Context, k8sClient client.Client, namespace string) (*cappv1alpha1.CappConfig, []string, error) {
+	cappConfig, err := GetCappConfig(ctx, k8sClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overrides, err := matchingCappConfigOverrides(ctx, k8sClient, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied := make([]string, 0, len(overrides))
+	for _, override := range overrides {
+		mergeCappConfigOverride(&cappConfig.Spec, override.Spec, namespace)
+		applied = append(applied, override.Name)
+	}
+
+	return cappConfig, applied, nil
+}
+
+// matchingCappConfigOverrides returns the CappConfigOverrides in the operator namespace whose
+// namespace selector matches the labels of the given namespace, sorted by name.
+func matchingCappConfigOverrides(ctx context.Context, k8sClient client.Client, namespace string) ([]cappv1alpha1.CappConfigOverride, error) {
+	overrideList := cappv1alpha1.CappConfigOverrideList{}
+	if err := k8sClient.List(ctx, &overrideList, client.InNamespace(cappmeta.CappNS)); err != nil {
+		return nil, fmt.Errorf("failed to list CappConfigOverrides: %w", err)
+	}
+	if len(overrideList.Items) == 0 {
+		return nil, nil
+	}
+
+	namespaceLabels, err := getNamespaceLabels(ctx, k8sClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []cappv1alpha1.CappConfigOverride
+	for _, override := range overrideList.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&override.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector in CappConfigOverride %q: %w", override.Name, err)
+		}
+		if selector.Matches(namespaceLabels) {
+			matching = append(matching, override)
+		}
+	}
+
+	slices.SortFunc(matching, func(a, b cappv1alpha1.CappConfigOverride) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return matching, nil
+}
+
+// getNamespaceLabels returns the labels of the given namespace. The namespace name label is always
+// present so overrides can select namespaces by name. A missing namespace yields only that label.
+func getNamespaceLabels(ctx context.Context, k8sClient client.Client, namespace string) (labels.Set, error) {
+	namespaceLabels := labels.Set{corev1.LabelMetadataName: namespace}
+
+	ns := corev1.Namespace{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return namespaceLabels, nil
+		}
+		return nil, fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+
+	for key, value := range ns.Labels {
+		namespaceLabels[key] = value
+	}
+	return namespaceLabels, nil
+}
+
+// mergeCappConfigOverride merges the set fields of the override over the given CappConfig spec.
+func mergeCappConfigOverride(spec *cappv1alpha1.CappConfigSpec, override cappv1alpha1.CappConfigOverrideSpec, namespace string) {
+	if override.AutoscaleConfig != nil {
+		mergeAutoscaleConfigOverride(&spec.AutoscaleConfig, *override.AutoscaleConfig, namespace)
+	}
+
+	if override.DefaultResources != nil {
+		spec.DefaultResources.Requests = mergeResourceLists(spec.DefaultResources.Requests, override.DefaultResources.Requests)
+		spec.DefaultResources.Limits = mergeResourceLists(spec.DefaultResources.Limits, override.DefaultResources.Limits)
+	}
+
+	if override.AllowedHostnamePatterns != nil {
+		spec.AllowedHostnamePatterns = slices.Clone(override.AllowedHostnamePatterns)
+	}
+
+	if override.RevisionHistoryLimit != nil {
+		spec.RevisionHistoryLimit = *override.RevisionHistoryLimit
+	}
+
+	if override.RevisionRetentionPeriod != nil {
+		spec.RevisionRetentionPeriod = override.RevisionRetentionPeriod.DeepCopy()
+	}
+
+	if override.MaxKafkaConsumers != nil {
+		spec.MaxKafkaConsumers = *override.MaxKafkaConsumers
+	}
+
+	if override.ImagePolicy != nil {
+		spec.ImagePolicy = *override.ImagePolicy.DeepCopy()
+	}
+
+	if override.NetworkConfig != nil {
+		spec.NetworkConfig = *override.NetworkConfig.DeepCopy()
+	}
+
+	if override.AuthConfig != nil {
+		spec.AuthConfig = *override.AuthConfig
+	}
+
+	if override.SecurityConfig != nil {
+		spec.SecurityConfig = *override.SecurityConfig.DeepCopy()
+	}
+}
+
+func mergeAutoscaleConfigOverride(autoscaleConfig *cappv1alpha1.AutoscaleConfig, override cappv1alpha1.AutoscaleConfigOverride, namespace string) {
+	setIfNotNil(&autoscaleConfig.RPS, override.RPS)
+	setIfNotNil(&autoscaleConfig.CPU, override.CPU)
+	setIfNotNil(&autoscaleConfig.Memory, override.Memory)
+	setIfNotNil(&autoscaleConfig.Concurrency, override.Concurrency)
+	setIfNotNil(&autoscaleConfig.ActivationScale, override.ActivationScale)
+	setIfNotNil(&autoscaleConfig.MinReplicasLimit, override.MinReplicasLimit)
+	setIfNotNil(&autoscaleConfig.MaxReplicasLimit, override.MaxReplicasLimit)
+	setIfNotNil(&autoscaleConfig.MaxScaleDelay, override.MaxScaleDelay)
+	setIfNotNil(&autoscaleConfig.MaxScaleToZeroRetention, override.MaxScaleToZeroRetention)
+
+	if override.RequireWarmReplica == nil {
+		return
+	}
+	warmNamespaces := slices.DeleteFunc(slices.Clone(autoscaleConfig.WarmNamespaces), func(ns string) bool { return ns == namespace })
+	if *override.RequireWarmReplica {
+		warmNamespaces = append(warmNamespaces, namespace)
+	}
+	autoscaleConfig.WarmNamespaces = warmNamespaces
+}
+
+func setIfNotNil[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+func mergeResourceLists(base, override corev1.ResourceList) corev1.ResourceList {
+	if base == nil && override == nil {
+		return nil
+	}
+	merged := make(corev1.ResourceList, len(base)+len(override))
+	for name, quantity := range base {
+		merged[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range override {
+		merged[name] = quantity.DeepCopy()
+	}
+	return merged
+}
@@ -0,0 +1,117 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const tenantLabelKey = "tenant"
+
+func newNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newCappConfigOverride(name, namespace string, spec cappv1alpha1.CappConfigOverrideSpec) *cappv1alpha1.CappConfigOverride {
+	return &cappv1alpha1.CappConfigOverride{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+}
+
+func tenantSelector(tenant string) metav1.LabelSelector {
+	return metav1.LabelSelector{MatchLabels: map[string]string{tenantLabelKey: tenant}}
+}
+
+func TestGetEffectiveCappConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns the global config when no override matches", func(t *testing.T) {
+		override := newCappConfigOverride("team-b", cappmeta.CappNS, cappv1alpha1.CappConfigOverrideSpec{
+			NamespaceSelector:    tenantSelector("b"),
+			RevisionHistoryLimit: ptr.To(3),
+		})
+		k8sClient := newFakeClient(newScheme(), newCappConfig(), override, newNamespace(cappNamespace, map[string]string{tenantLabelKey: "a"}))
+
+		cfg, applied, err := GetEffectiveCappConfig(ctx, k8sClient, cappNamespace)
+		require.NoError(t, err)
+		require.Empty(t, applied)
+		require.Equal(t, newCappConfig().Spec, cfg.Spec)
+	})
+
+	t.Run("merges matching overrides in name order", func(t *testing.T) {
+		first := newCappConfigOverride("a-team", cappmeta.CappNS, cappv1alpha1.CappConfigOverrideSpec{
+			NamespaceSelector:       tenantSelector("a"),
+			AutoscaleConfig:         &cappv1alpha1.AutoscaleConfigOverride{RPS: ptr.To(50), MaxReplicasLimit: ptr.To(5)},
+			RevisionHistoryLimit:    ptr.To(3),
+			RevisionRetentionPeriod: &metav1.Duration{Duration: 72 * time.Hour},
+		})
+		second := newCappConfigOverride("b-production", cappmeta.CappNS, cappv1alpha1.CappConfigOverrideSpec{
+			NamespaceSelector:       tenantSelector("a"),
+			AutoscaleConfig:         &cappv1alpha1.AutoscaleConfigOverride{MaxReplicasLimit: ptr.To(20), RequireWarmReplica: ptr.To(true)},
+			AllowedHostnamePatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.team-a\.com`}},
+			DefaultResources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			NetworkConfig:  &cappv1alpha1.NetworkConfig{DefaultDeny: true},
+			AuthConfig:     &cappv1alpha1.AuthConfig{ProxyImage: "registry.team-b.com/oauth2-proxy:v7"},
+			SecurityConfig: &cappv1alpha1.SecurityConfig{RunAsNonRoot: true},
+		})
+		k8sClient := newFakeClient(newScheme(), newCappConfig(), second, first,
+			newNamespace(cappNamespace, map[string]string{tenantLabelKey: "a"}))
+
+		cfg, applied, err := GetEffectiveCappConfig(ctx, k8sClient, cappNamespace)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a-team", "b-production"}, applied)
+		require.Equal(t, 50, cfg.Spec.AutoscaleConfig.RPS)
+		require.Equal(t, 80, cfg.Spec.AutoscaleConfig.CPU)
+		require.Equal(t, 20, cfg.Spec.AutoscaleConfig.MaxReplicasLimit)
+		require.Equal(t, []string{cappNamespace}, cfg.Spec.AutoscaleConfig.WarmNamespaces)
+		require.Equal(t, 3, cfg.Spec.RevisionHistoryLimit)
+		require.Equal(t, &metav1.Duration{Duration: 72 * time.Hour}, cfg.Spec.RevisionRetentionPeriod)
+		require.Equal(t, []cappv1alpha1.HostnamePattern{{Match: `.*\.team-a\.com`}}, cfg.Spec.AllowedHostnamePatterns)
+		require.True(t, resource.MustParse("1Gi").Equal(cfg.Spec.DefaultResources.Limits[corev1.ResourceMemory]))
+		require.True(t, cfg.Spec.NetworkConfig.DefaultDeny)
+		require.Equal(t, "registry.team-b.com/oauth2-proxy:v7", cfg.Spec.AuthConfig.ProxyImage)
+		require.True(t, cfg.Spec.SecurityConfig.RunAsNonRoot)
+	})
+
+	t.Run("ignores overrides outside the operator namespace", func(t *testing.T) {
+		override := newCappConfigOverride("tenant-owned", cappNamespace, cappv1alpha1.CappConfigOverrideSpec{
+			NamespaceSelector:    metav1.LabelSelector{},
+			RevisionHistoryLimit: ptr.To(100),
+		})
+		k8sClient := newFakeClient(newScheme(), newCappConfig(), override)
+
+		cfg, applied, err := GetEffectiveCappConfig(ctx, k8sClient, cappNamespace)
+		require.NoError(t, err)
+		require.Empty(t, applied)
+		require.Zero(t, cfg.Spec.RevisionHistoryLimit)
+	})
+
+	t.Run("matches by namespace name when the namespace does not exist", func(t *testing.T) {
+		override := newCappConfigOverride("by-name", cappmeta.CappNS, cappv1alpha1.CappConfigOverrideSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: cappNamespace}},
+			MaxKafkaConsumers: ptr.To(int32(2)),
+		})
+		k8sClient := newFakeClient(newScheme(), newCappConfig(), override)
+
+		cfg, applied, err := GetEffectiveCappConfig(ctx, k8sClient, cappNamespace)
+		require.NoError(t, err)
+		require.Equal(t, []string{"by-name"}, applied)
+		require.Equal(t, int32(2), cfg.Spec.MaxKafkaConsumers)
+	})
+
+	t.Run("returns an error when the global config is missing", func(t *testing.T) {
+		_, _, err := GetEffectiveCappConfig(ctx, newFakeClient(newScheme()), cappNamespace)
+		require.Error(t, err)
+	})
+}
@@ -106,19 +106,15 @@ func (c CertificateManager) createOrUpdate(ctx context.Context, capp cappv1alpha
 
 	if err := c.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: certificateFromCapp.Name}, &certificate); err != nil {
 		if errors.IsNotFound(err) {
-			return createManagedResource(ctx, c.K8sClient, c.CreateResource, c.EventRecorder, &capp, &certificateFromCapp,
+			return createManagedResource(ctx, c.K8sClient, c.ApplyResource, c.EventRecorder, &capp, &certificateFromCapp,
 				Certificate, eventCappCertificateCreated, eventCappCertificateCreationFailed)
 		}
 		return fmt.Errorf("failed to get Certificate %q: %w", certificateFromCapp.Name, err)
 	}
 
-	orig := certificate.DeepCopy()
-	certificate.Spec = *certificateFromCapp.Spec.DeepCopy()
-	if err := ensureOwnerReference(c.K8sClient, &capp, &certificate, Certificate); err != nil {
-		return err
-	}
-	if err := updateManagedResourceIfNeeded(ctx, c.UpdateResource, &certificate, orig.Spec, certificate.Spec, orig.OwnerReferences); err != nil {
-		return fmt.Errorf("update Certificate %q: %w", certificate.Name, err)
+	if err := applyManagedResourceIfNeeded(ctx, c.K8sClient, c.ApplyResource, c.EventRecorder, &capp, &certificate, &certificateFromCapp,
+		certificate.Spec, certificateFromCapp.Spec, Certificate); err != nil {
+		return fmt.Errorf("apply Certificate %q: %w", certificate.Name, err)
 	}
 
 	return nil
@@ -0,0 +1,121 @@
+package resourcemanagers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// configContent is the content of a mounted ConfigMap or Secret that goes into the config hash.
+type configContent struct {
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// setConfigHash stamps the hash of the content of the ConfigMaps and Secrets used by capp into the
+// revision template annotations of knativeService, so that Knative rolls out a new revision when the
+// content changes.
+func (k KnativeServiceManager) setConfigHash(ctx context.Context, capp cappv1alpha1.Capp, knativeService *knativev1.Service) error {
+	hash, err := configHash(ctx, k.K8sClient, capp)
+	if err != nil || hash == "" {
+		return err
+	}
+	if knativeService.Spec.Template.Annotations == nil {
+		knativeService.Spec.Template.Annotations = map[string]string{}
+	}
+	knativeService.Spec.Template.Annotations[cappmeta.ConfigHashAnnotationKey] = hash
+	return nil
+}
+
+// configHash returns the hash of the content of the ConfigMaps and Secrets mounted by capp and of the
+// Secrets its external secrets are synced into and its login is configured with, or an empty string
+// if it uses none. An object that does not exist yet contributes no content.
+func configHash(ctx context.Context, c client.Client, capp cappv1alpha1.Capp) (string, error) {
+	volumes := capp.Spec.VolumesSpec
+	secrets := capp.Spec.SecretsSpec.Secrets
+	auth := capp.Spec.RouteSpec.Auth
+	if len(volumes.ConfigMapVolumes) == 0 && len(volumes.SecretVolumes) == 0 && len(secrets) == 0 && auth == nil {
+		return "", nil
+	}
+
+	secretNames := make([]string, 0, len(volumes.SecretVolumes)+len(secrets)+1)
+	for _, volume := range volumes.SecretVolumes {
+		secretNames = append(secretNames, volume.SecretName)
+	}
+	for _, secret := range secrets {
+		secretNames = append(secretNames, ExternalSecretName(capp, secret))
+	}
+	if auth != nil {
+		secretNames = append(secretNames, auth.ClientSecret)
+	}
+
+	contents := make([]configContent, 0, len(volumes.ConfigMapVolumes)+len(secretNames))
+	for _, volume := range volumes.ConfigMapVolumes {
+		configMap := corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: volume.ConfigMapName}, &configMap); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get ConfigMap %q: %w", volume.ConfigMapName, err)
+			}
+		}
+		contents = append(contents, configContent{
+			Kind:       "ConfigMap",
+			Name:       volume.ConfigMapName,
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		})
+	}
+	for _, name := range secretNames {
+		secret := corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: name}, &secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get Secret %q: %w", name, err)
+			}
+		}
+		contents = append(contents, configContent{Kind: "Secret", Name: name, BinaryData: secret.Data})
+	}
+
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return "", fmt.Errorf("hash mounted config of Capp %q: %w", capp.Name, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// MountsConfigMap reports whether capp mounts the ConfigMap with the given name.
+func MountsConfigMap(capp cappv1alpha1.Capp, name string) bool {
+	for _, volume := range capp.Spec.VolumesSpec.ConfigMapVolumes {
+		if volume.ConfigMapName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesSecret reports whether capp mounts the Secret with the given name, sets environment
+// variables from it or configures its login with it.
+func UsesSecret(capp cappv1alpha1.Capp, name string) bool {
+	for _, volume := range capp.Spec.VolumesSpec.SecretVolumes {
+		if volume.SecretName == name {
+			return true
+		}
+	}
+	for _, secret := range capp.Spec.SecretsSpec.Secrets {
+		if ExternalSecretName(capp, secret) == name {
+			return true
+		}
+	}
+	return capp.Spec.RouteSpec.Auth != nil && capp.Spec.RouteSpec.Auth.ClientSecret == name
+}
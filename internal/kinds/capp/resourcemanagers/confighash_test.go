@@ -0,0 +1,127 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	configMapName    = "app-config"
+	configSecretName = "app-credentials"
+)
+
+func cappWithConfigVolumes() cappv1alpha1.Capp {
+	capp := newKsvcCapp()
+	capp.Spec.VolumesSpec.ConfigMapVolumes = []cappv1alpha1.ConfigMapVolume{{Name: "config", ConfigMapName: configMapName}}
+	capp.Spec.VolumesSpec.SecretVolumes = []cappv1alpha1.SecretVolume{{Name: "credentials", SecretName: configSecretName}}
+	return capp
+}
+
+func newConfigMap(value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: cappNamespace},
+		Data:       map[string]string{"mode": value},
+	}
+}
+
+func newConfigSecret(value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: configSecretName, Namespace: cappNamespace},
+		Data:       map[string][]byte{"password": []byte(value)},
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("is empty when no config is mounted", func(t *testing.T) {
+		hash, err := configHash(ctx, newFakeClient(newScheme()), newKsvcCapp())
+		require.NoError(t, err)
+		require.Empty(t, hash)
+	})
+
+	t.Run("changes with the content of a mounted ConfigMap or Secret", func(t *testing.T) {
+		capp := cappWithConfigVolumes()
+
+		missing, err := configHash(ctx, newFakeClient(newScheme()), capp)
+		require.NoError(t, err)
+		require.NotEmpty(t, missing)
+
+		original, err := configHash(ctx, newFakeClient(newScheme(), newConfigMap("fast"), newConfigSecret("s3cr3t")), capp)
+		require.NoError(t, err)
+		require.NotEqual(t, missing, original)
+
+		same, err := configHash(ctx, newFakeClient(newScheme(), newConfigMap("fast"), newConfigSecret("s3cr3t")), capp)
+		require.NoError(t, err)
+		require.Equal(t, original, same)
+
+		configMapChanged, err := configHash(ctx, newFakeClient(newScheme(), newConfigMap("slow"), newConfigSecret("s3cr3t")), capp)
+		require.NoError(t, err)
+		require.NotEqual(t, original, configMapChanged)
+
+		secretChanged, err := configHash(ctx, newFakeClient(newScheme(), newConfigMap("fast"), newConfigSecret("rotated")), capp)
+		require.NoError(t, err)
+		require.NotEqual(t, original, secretChanged)
+	})
+
+	t.Run("changes with the content of the Secret of an external secret", func(t *testing.T) {
+		capp := newKsvcCapp()
+		capp.Spec.SecretsSpec.Secrets = []cappv1alpha1.ExternalSecret{{Name: "db"}}
+		synced := func(value string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: cappName + "-db", Namespace: cappNamespace},
+				Data:       map[string][]byte{"DB_PASSWORD": []byte(value)},
+			}
+		}
+
+		original, err := configHash(ctx, newFakeClient(newScheme(), synced("s3cr3t")), capp)
+		require.NoError(t, err)
+		require.NotEmpty(t, original)
+
+		rotated, err := configHash(ctx, newFakeClient(newScheme(), synced("rotated")), capp)
+		require.NoError(t, err)
+		require.NotEqual(t, original, rotated)
+	})
+
+	t.Run("changes with the content of the client Secret of the login", func(t *testing.T) {
+		capp := newKsvcCapp()
+		capp.Spec.RouteSpec.Auth = &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: configSecretName}
+
+		original, err := configHash(ctx, newFakeClient(newScheme(), newConfigSecret("s3cr3t")), capp)
+		require.NoError(t, err)
+		require.NotEmpty(t, original)
+
+		rotated, err := configHash(ctx, newFakeClient(newScheme(), newConfigSecret("rotated")), capp)
+		require.NoError(t, err)
+		require.NotEqual(t, original, rotated)
+		require.True(t, UsesSecret(capp, configSecretName))
+		require.False(t, MountsConfigMap(capp, configSecretName))
+	})
+}
+
+func TestKnativeServiceManagerConfigHash(t *testing.T) {
+	ctx := context.Background()
+	capp := cappWithConfigVolumes()
+	km, _ := newKsvcManager(newFakeClient(newKsvcScheme(), newConfigMap("fast"), newConfigSecret("s3cr3t")))
+
+	require.NoError(t, km.createOrUpdate(ctx, capp))
+
+	got := &knativev1.Service{}
+	require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+	hash, err := configHash(ctx, km.K8sClient, capp)
+	require.NoError(t, err)
+	require.Equal(t, hash, got.Spec.Template.Annotations[cappmeta.ConfigHashAnnotationKey])
+
+	volumes := got.Spec.Template.Spec.Volumes
+	require.Len(t, volumes, 2)
+	require.Equal(t, configMapName, volumes[0].ConfigMap.Name)
+	require.Equal(t, configSecretName, volumes[1].Secret.SecretName)
+}
@@ -100,41 +100,35 @@ func (r DNSRecordManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.
 
 	if err := r.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: dnsRecordFromCapp.Name}, &dnsRecord); err != nil {
 		if errors.IsNotFound(err) {
-			return createManagedResource(ctx, r.K8sClient, r.CreateResource, r.EventRecorder, &capp, &dnsRecordFromCapp,
+			return createManagedResource(ctx, r.K8sClient, r.ApplyResource, r.EventRecorder, &capp, &dnsRecordFromCapp,
 				DNSRecord, eventCappDNSRecordCreated, eventCappDNSRecordCreationFailed)
 		}
 		return fmt.Errorf("failed to get DNSRecord %q: %w", dnsRecordFromCapp.Name, err)
 	}
 
-	needs, err := r.dnsRecordNeedsUpdate(dnsRecord, dnsRecordFromCapp, &capp)
-	if err != nil {
+	if err := ensureOwnerReference(r.K8sClient, &capp, &dnsRecordFromCapp, DNSRecord); err != nil {
 		return err
 	}
-	if !needs {
-		return nil
-	}
-
-	orig := dnsRecord.DeepCopy()
-	if err := ensureOwnerReference(r.K8sClient, &capp, &dnsRecord, DNSRecord); err != nil {
+	if err := setAppliedSpecHash(&dnsRecordFromCapp); err != nil {
 		return err
 	}
-	dnsRecord.Spec.ForProvider = *dnsRecordFromCapp.Spec.ForProvider.DeepCopy()
-	if dnsRecordFromCapp.Spec.ProviderConfigReference != nil {
-		dnsRecord.Spec.ProviderConfigReference = dnsRecordFromCapp.Spec.ProviderConfigReference.DeepCopy()
-	} else {
-		dnsRecord.Spec.ProviderConfigReference = nil
+	needs, err := r.dnsRecordNeedsUpdate(dnsRecord, dnsRecordFromCapp, &capp)
+	if err != nil {
+		return err
 	}
-
-	if !managedResourceNeedsUpdate(orig.Spec, dnsRecord.Spec, orig.OwnerReferences, dnsRecord.OwnerReferences) {
+	if !needs {
 		return nil
 	}
 
-	return r.UpdateResource(ctx, &dnsRecord)
+	return applyManagedResource(ctx, r.ApplyResource, r.EventRecorder, &capp, &dnsRecord, &dnsRecordFromCapp, DNSRecord)
 }
 
+// dnsRecordNeedsUpdate compares only the spec fields and annotations the operator applies, since
+// the provider fills in the remaining ones.
 func (r DNSRecordManager) dnsRecordNeedsUpdate(current, desired dnsrecordv1alpha1.CNAMERecord, capp *cappv1alpha1.Capp) (bool, error) {
 	if !equality.Semantic.DeepEqual(current.Spec.ForProvider, desired.Spec.ForProvider) ||
-		!equality.Semantic.DeepEqual(current.Spec.ProviderConfigReference, desired.Spec.ProviderConfigReference) {
+		!equality.Semantic.DeepEqual(current.Spec.ProviderConfigReference, desired.Spec.ProviderConfigReference) ||
+		!isSubset(desired.Annotations, current.Annotations) {
 		return true, nil
 	}
 	ok, err := controllerutil.HasOwnerReference(current.OwnerReferences, capp, r.K8sClient.Scheme())
@@ -137,18 +137,14 @@ func (k DomainMappingManager) createOrUpdate(ctx context.Context, capp cappv1alp
 
 	if err := k.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: domainMappingFromCapp.Name}, &domainMapping); err != nil {
 		if errors.IsNotFound(err) {
-			return createManagedResource(ctx, k.K8sClient, k.CreateResource, k.EventRecorder, &capp, &domainMappingFromCapp,
+			return createManagedResource(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, &domainMappingFromCapp,
 				DomainMapping, eventCappDomainMappingCreated, eventCappDomainMappingCreationFailed)
 		}
 		return fmt.Errorf("failed to get DomainMapping %q: %w", domainMappingFromCapp.Name, err)
 	}
 
-	orig := domainMapping.DeepCopy()
-	domainMapping.Spec = *domainMappingFromCapp.Spec.DeepCopy()
-	if err := ensureOwnerReference(k.K8sClient, &capp, &domainMapping, DomainMapping); err != nil {
-		return err
-	}
-	return updateManagedResourceIfNeeded(ctx, k.UpdateResource, &domainMapping, orig.Spec, domainMapping.Spec, orig.OwnerReferences)
+	return applyManagedResourceIfNeeded(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, &domainMapping, &domainMappingFromCapp,
+		domainMapping.Spec, domainMappingFromCapp.Spec, DomainMapping)
 }
 
 // getPreviousDomainMappings returns a list of all DomainMapping objects that are related to the given Capp.
@@ -0,0 +1,194 @@
+package resourcemanagers
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	eventDriftCorrected = "DriftCorrected"
+
+	// maxDriftFieldsInSummary bounds the number of fields listed when a drift is summarized.
+	maxDriftFieldsInSummary = 5
+	// maxDriftValueLen bounds the length of a value shown in a drift summary.
+	maxDriftValueLen = 64
+)
+
+// Drift describes out-of-band changes to the spec fields the operator applies on a child resource.
+type Drift struct {
+	Kind string
+	Name string
+	// Fields lists the drifted fields as "path: current -> desired", ordered by path.
+	Fields []string
+}
+
+// String returns a compact summary of the drift, listing at most maxDriftFieldsInSummary fields.
+func (d Drift) String() string {
+	fields := d.Fields
+	more := ""
+	if len(fields) > maxDriftFieldsInSummary {
+		more = fmt.Sprintf(" (+%d more)", len(fields)-maxDriftFieldsInSummary)
+		fields = fields[:maxDriftFieldsInSummary]
+	}
+	return fmt.Sprintf("%s %s: %s%s", d.Kind, d.Name, strings.Join(fields, ", "), more)
+}
+
+// DriftReport collects the drift left uncorrected by the resource managers during a reconcile.
+// It is safe for concurrent use.
+type DriftReport struct {
+	mu     sync.Mutex
+	drifts []Drift
+}
+
+// Drifts returns the recorded drifts ordered by kind and name.
+func (r *DriftReport) Drifts() []Drift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	drifts := slices.Clone(r.drifts)
+	slices.SortFunc(drifts, func(a, b Drift) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return drifts
+}
+
+func (r *DriftReport) record(drift Drift) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drifts = append(r.drifts, drift)
+}
+
+type driftReportKey struct{}
+
+// WithDriftReport returns a context in which the resource managers record uncorrected drift into report.
+func WithDriftReport(ctx context.Context, report *DriftReport) context.Context {
+	return context.WithValue(ctx, driftReportKey{}, report)
+}
+
+func driftReportFrom(ctx context.Context) *DriftReport {
+	report, _ := ctx.Value(driftReportKey{}).(*DriftReport)
+	return report
+}
+
+// DriftCorrectionPaused reports whether the Capp asks the operator to leave drifted child resources as they are.
+func DriftCorrectionPaused(capp cappv1alpha1.Capp) bool {
+	return capp.Annotations[cappmeta.DriftCorrectionAnnotationKey] == cappmeta.DriftCorrectionPaused
+}
+
+// setAppliedSpecHash annotates obj with the hash of its spec and labels, so that a later difference
+// between the spec of the live object and a desired spec with the same hash can be told apart from a
+// Capp change. Hashing the labels makes a label the operator stops setting change the hash, so that
+// the object is applied again and the label removed.
+func setAppliedSpecHash(obj client.Object) error {
+	spec, err := specOf(obj)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(struct {
+		Spec   any               `json:"spec"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}{Spec: spec, Labels: obj.GetLabels()})
+	if err != nil {
+		return fmt.Errorf("hash spec of %s: %w", obj.GetName(), err)
+	}
+	sum := sha256.Sum256(data)
+
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[cappmeta.AppliedSpecHashAnnotationKey] = hex.EncodeToString(sum[:8])
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// driftedFields returns the spec fields set on desired whose value on existing differs, provided that
+// existing was last applied with the same spec as desired. Fields desired leaves unset are ignored,
+// since they are owned by other controllers.
+func driftedFields(existing, desired client.Object) ([]string, error) {
+	hash := desired.GetAnnotations()[cappmeta.AppliedSpecHashAnnotationKey]
+	if hash == "" || existing.GetAnnotations()[cappmeta.AppliedSpecHashAnnotationKey] != hash {
+		return nil, nil
+	}
+
+	desiredSpec, err := specOf(desired)
+	if err != nil {
+		return nil, err
+	}
+	existingSpec, err := specOf(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	diffFields("spec", desiredSpec, existingSpec, &fields)
+	return fields, nil
+}
+
+func specOf(obj client.Object) (any, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s to unstructured: %w", obj.GetName(), err)
+	}
+	return content["spec"], nil
+}
+
+func diffFields(path string, desired, current any, fields *[]string) {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return
+	case map[string]any:
+		currentValue, _ := current.(map[string]any)
+		keys := make([]string, 0, len(desiredValue))
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			diffFields(path+"."+key, desiredValue[key], currentValue[key], fields)
+		}
+	case []any:
+		currentValue, _ := current.([]any)
+		if len(currentValue) != len(desiredValue) {
+			*fields = append(*fields, fmt.Sprintf("%s: %d items -> %d items", path, len(currentValue), len(desiredValue)))
+			return
+		}
+		for i := range desiredValue {
+			diffFields(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], currentValue[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(desired, current) {
+			*fields = append(*fields, fmt.Sprintf("%s: %s -> %s", path, formatDriftValue(current), formatDriftValue(desired)))
+		}
+	}
+}
+
+func formatDriftValue(value any) string {
+	if value == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(data) > maxDriftValueLen {
+		return string(data[:maxDriftValueLen]) + "..."
+	}
+	return string(data)
+}
@@ -0,0 +1,97 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kafkasourcev1 "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1"
+)
+
+func newKafkaSourceFixture(topic string) *kafkasourcev1.KafkaSource {
+	return &kafkasourcev1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: cappNamespace},
+		Spec: kafkasourcev1.KafkaSourceSpec{
+			Topics:        []string{topic},
+			ConsumerGroup: "test-group",
+		},
+	}
+}
+
+func TestDriftedFields(t *testing.T) {
+	t.Run("ignores objects last applied with another spec", func(t *testing.T) {
+		desired := newKafkaSourceFixture("topic-a")
+		require.NoError(t, setAppliedSpecHash(desired))
+		existing := newKafkaSourceFixture("topic-b")
+
+		fields, err := driftedFields(existing, desired)
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+
+	t.Run("lists the applied fields changed out of band", func(t *testing.T) {
+		desired := newKafkaSourceFixture("topic-a")
+		require.NoError(t, setAppliedSpecHash(desired))
+		existing := desired.DeepCopy()
+		existing.Spec.Topics = []string{"topic-b", "topic-c"}
+		existing.Spec.ConsumerGroup = "other-group"
+
+		fields, err := driftedFields(existing, desired)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`spec.consumerGroup: "other-group" -> "test-group"`,
+			"spec.topics: 2 items -> 1 items",
+		}, fields)
+	})
+
+	t.Run("ignores fields left unset in the desired spec", func(t *testing.T) {
+		desired := newKafkaSourceFixture("topic-a")
+		require.NoError(t, setAppliedSpecHash(desired))
+		existing := desired.DeepCopy()
+		existing.Spec.InitialOffset = "latest"
+
+		fields, err := driftedFields(existing, desired)
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+}
+
+func TestDriftString(t *testing.T) {
+	drift := Drift{Kind: KafkaSource, Name: "test", Fields: []string{"a", "b", "c", "d", "e", "f", "g"}}
+	assert.Equal(t, "KafkaSource test: a, b, c, d, e (+2 more)", drift.String())
+}
+
+func TestDriftReport(t *testing.T) {
+	report := &DriftReport{}
+	ctx := WithDriftReport(context.Background(), report)
+
+	driftReportFrom(ctx).record(Drift{Kind: KnativeService, Name: "b"})
+	driftReportFrom(ctx).record(Drift{Kind: DomainMapping, Name: "a"})
+	driftReportFrom(context.Background()).record(Drift{Kind: Certificate, Name: "ignored"})
+
+	drifts := report.Drifts()
+	require.Len(t, drifts, 2)
+	assert.Equal(t, DomainMapping, drifts[0].Kind)
+	assert.Equal(t, KnativeService, drifts[1].Kind)
+}
+
+func TestSetAppliedSpecHash(t *testing.T) {
+	first := newKafkaSourceFixture("topic-a")
+	first.Annotations = map[string]string{"keep": "me"}
+	require.NoError(t, setAppliedSpecHash(first))
+	second := newKafkaSourceFixture("topic-a")
+	require.NoError(t, setAppliedSpecHash(second))
+	other := newKafkaSourceFixture("topic-b")
+	require.NoError(t, setAppliedSpecHash(other))
+	labeled := newKafkaSourceFixture("topic-a")
+	labeled.Labels = map[string]string{"added": "label"}
+	require.NoError(t, setAppliedSpecHash(labeled))
+
+	assert.Equal(t, "me", first.Annotations["keep"])
+	assert.Equal(t, first.Annotations[cappmeta.AppliedSpecHashAnnotationKey], second.Annotations[cappmeta.AppliedSpecHashAnnotationKey])
+	assert.NotEqual(t, first.Annotations[cappmeta.AppliedSpecHashAnnotationKey], other.Annotations[cappmeta.AppliedSpecHashAnnotationKey])
+	assert.NotEqual(t, first.Annotations[cappmeta.AppliedSpecHashAnnotationKey], labeled.Annotations[cappmeta.AppliedSpecHashAnnotationKey])
+}
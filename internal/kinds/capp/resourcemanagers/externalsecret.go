@@ -0,0 +1,219 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ExternalSecret                    = "ExternalSecret"
+	eventExternalSecretCreationFailed = "ExternalSecretCreationFailed"
+	eventExternalSecretCreated        = "ExternalSecretCreated"
+	defaultSecretRefreshInterval      = time.Hour
+	defaultSecretStoreKind            = "SecretStore"
+)
+
+// ExternalSecretGVK is the group, version and kind of the ExternalSecrets of the External Secrets
+// Operator. The operator does not depend on its Go module, so ExternalSecrets are handled as
+// unstructured objects.
+var ExternalSecretGVK = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1", Kind: "ExternalSecret"}
+
+// externalSecretSpec is the part of the spec of an ExternalSecret the operator sets.
+type externalSecretSpec struct {
+	RefreshInterval string                     `json:"refreshInterval"`
+	SecretStoreRef  externalSecretStoreRef     `json:"secretStoreRef"`
+	Target          externalSecretTarget       `json:"target"`
+	Data            []externalSecretDataTarget `json:"data"`
+}
+
+type externalSecretStoreRef struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type externalSecretTarget struct {
+	Name           string `json:"name"`
+	CreationPolicy string `json:"creationPolicy"`
+}
+
+type externalSecretDataTarget struct {
+	SecretKey string                  `json:"secretKey"`
+	RemoteRef externalSecretRemoteRef `json:"remoteRef"`
+}
+
+type externalSecretRemoteRef struct {
+	Key      string `json:"key"`
+	Property string `json:"property,omitempty"`
+}
+
+type ExternalSecretManager struct {
+	rclient.ResourceManagerClient
+	EventRecorder events.EventRecorder
+}
+
+// ExternalSecretName returns the name of the ExternalSecret of secret and of the Secret it syncs.
+func ExternalSecretName(capp cappv1alpha1.Capp, secret cappv1alpha1.ExternalSecret) string {
+	return fmt.Sprintf("%s-%s", capp.Name, secret.Name)
+}
+
+// NewExternalSecret returns an empty ExternalSecret, to be filled by the client.
+func NewExternalSecret() *unstructured.Unstructured {
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(ExternalSecretGVK)
+	return externalSecret
+}
+
+// prepareSpec returns the spec of the ExternalSecret of secret. Each environment variable of the
+// secret is synced into the key of the Secret of the same name.
+func (e ExternalSecretManager) prepareSpec(capp cappv1alpha1.Capp, secret cappv1alpha1.ExternalSecret) externalSecretSpec {
+	refreshInterval := defaultSecretRefreshInterval
+	if secret.RefreshInterval != nil {
+		refreshInterval = secret.RefreshInterval.Duration
+	}
+	storeKind := secret.StoreRef.Kind
+	if storeKind == "" {
+		storeKind = defaultSecretStoreKind
+	}
+
+	spec := externalSecretSpec{
+		RefreshInterval: refreshInterval.String(),
+		SecretStoreRef:  externalSecretStoreRef{Name: secret.StoreRef.Name, Kind: storeKind},
+		Target:          externalSecretTarget{Name: ExternalSecretName(capp, secret), CreationPolicy: "Owner"},
+	}
+	for _, env := range secret.Env {
+		spec.Data = append(spec.Data, externalSecretDataTarget{
+			SecretKey: env.Name,
+			RemoteRef: externalSecretRemoteRef{Key: env.Key, Property: env.Property},
+		})
+	}
+	return spec
+}
+
+// prepareResource prepares the ExternalSecrets of the secrets of the Capp.
+func (e ExternalSecretManager) prepareResource(capp cappv1alpha1.Capp) ([]*unstructured.Unstructured, error) {
+	externalSecrets := make([]*unstructured.Unstructured, 0, len(capp.Spec.SecretsSpec.Secrets))
+
+	for _, secret := range capp.Spec.SecretsSpec.Secrets {
+		spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ptr.To(e.prepareSpec(capp, secret)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare ExternalSecret of secret %q: %w", secret.Name, err)
+		}
+		externalSecret := NewExternalSecret()
+		externalSecret.SetName(ExternalSecretName(capp, secret))
+		externalSecret.SetNamespace(capp.Namespace)
+		externalSecret.SetLabels(cappmeta.ManagedResourceLabels(capp.Name))
+		externalSecret.Object["spec"] = spec
+		externalSecrets = append(externalSecrets, externalSecret)
+	}
+
+	return externalSecrets, nil
+}
+
+// appliedExternalSecretSpec returns the fields of the spec of externalSecret the operator sets,
+// leaving out those defaulted by the External Secrets Operator, so that it can be compared with the
+// desired spec.
+func appliedExternalSecretSpec(externalSecret *unstructured.Unstructured) (externalSecretSpec, error) {
+	spec := externalSecretSpec{}
+	content, _, err := unstructured.NestedMap(externalSecret.Object, "spec")
+	if err != nil {
+		return spec, fmt.Errorf("failed to read spec of ExternalSecret %q: %w", externalSecret.GetName(), err)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec); err != nil {
+		return spec, fmt.Errorf("failed to read spec of ExternalSecret %q: %w", externalSecret.GetName(), err)
+	}
+	return spec, nil
+}
+
+// getPreviousExternalSecrets returns a list of all ExternalSecrets the operator created for the given Capp.
+func (e ExternalSecretManager) getPreviousExternalSecrets(ctx context.Context, capp cappv1alpha1.Capp) (*unstructured.UnstructuredList, error) {
+	externalSecrets := &unstructured.UnstructuredList{}
+	externalSecrets.SetGroupVersionKind(ExternalSecretGVK.GroupVersion().WithKind(ExternalSecret + "List"))
+	if err := listManagedResources(ctx, e.K8sClient, capp, externalSecrets, ExternalSecret, nil); err != nil {
+		return externalSecrets, err
+	}
+	return externalSecrets, nil
+}
+
+// CleanUp attempts to delete the ExternalSecrets of a given Capp resource that no longer belong to
+// any of its secrets, or all of them once the Capp is deleted.
+func (e ExternalSecretManager) CleanUp(ctx context.Context, capp cappv1alpha1.Capp) error {
+	declared := make(map[string]bool, len(capp.Spec.SecretsSpec.Secrets))
+	if capp.DeletionTimestamp == nil {
+		for _, secret := range capp.Spec.SecretsSpec.Secrets {
+			declared[ExternalSecretName(capp, secret)] = true
+		}
+	}
+
+	externalSecrets, err := e.getPreviousExternalSecrets(ctx, capp)
+	if err != nil {
+		return err
+	}
+	var resources []*unstructured.Unstructured
+	for i := range externalSecrets.Items {
+		if !declared[externalSecrets.Items[i].GetName()] {
+			resources = append(resources, &externalSecrets.Items[i])
+		}
+	}
+	return deleteOwnedResources(ctx, e.K8sClient, &capp, resources)
+}
+
+// IsRequired is responsible to determine if resource ExternalSecret is required.
+func (e ExternalSecretManager) IsRequired(capp cappv1alpha1.Capp) bool {
+	return len(capp.Spec.SecretsSpec.Secrets) > 0
+}
+
+// Manage creates or updates the ExternalSecrets of the provided Capp if they are required.
+// If they are not, then it cleans up those it created before.
+func (e ExternalSecretManager) Manage(ctx context.Context, capp cappv1alpha1.Capp) error {
+	if e.IsRequired(capp) {
+		return e.createOrUpdate(ctx, capp)
+	}
+
+	return e.CleanUp(ctx, capp)
+}
+
+// createOrUpdate creates or updates the ExternalSecrets of the Capp, and deletes those of secrets
+// since removed from it.
+func (e ExternalSecretManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Capp) error {
+	generatedExternalSecrets, err := e.prepareResource(capp)
+	if err != nil {
+		return err
+	}
+
+	for i, externalSecret := range generatedExternalSecrets {
+		existingExternalSecret := NewExternalSecret()
+		if err := e.K8sClient.Get(ctx, client.ObjectKeyFromObject(externalSecret), existingExternalSecret); err != nil {
+			if errors.IsNotFound(err) {
+				if err := createManagedResource(ctx, e.K8sClient, e.ApplyResource, e.EventRecorder, &capp, externalSecret,
+					ExternalSecret, eventExternalSecretCreated, eventExternalSecretCreationFailed); err != nil {
+					return err
+				}
+				continue
+			}
+			return fmt.Errorf("failed to get ExternalSecret %q: %w", externalSecret.GetName(), err)
+		}
+
+		existingSpec, err := appliedExternalSecretSpec(existingExternalSecret)
+		if err != nil {
+			return err
+		}
+		if err := applyManagedResourceIfNeeded(ctx, e.K8sClient, e.ApplyResource, e.EventRecorder, &capp, existingExternalSecret, externalSecret,
+			existingSpec, e.prepareSpec(capp, capp.Spec.SecretsSpec.Secrets[i]), ExternalSecret); err != nil {
+			return err
+		}
+	}
+
+	return e.CleanUp(ctx, capp)
+}
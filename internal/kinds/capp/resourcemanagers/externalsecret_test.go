@@ -0,0 +1,198 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	externalSecretName = "db"
+	syncedSecretName   = cappName + "-" + externalSecretName
+)
+
+func newExternalSecretManager(k8sClient client.Client) ExternalSecretManager {
+	return ExternalSecretManager{
+		ResourceManagerClient: rclient.ResourceManagerClient{K8sClient: k8sClient, Log: logr.Discard()},
+		EventRecorder:         events.NewFakeRecorder(10),
+	}
+}
+
+func newExternalSecret() cappv1alpha1.ExternalSecret {
+	return cappv1alpha1.ExternalSecret{
+		Name:     externalSecretName,
+		StoreRef: cappv1alpha1.SecretStoreRef{Name: "vault"},
+		Env: []cappv1alpha1.ExternalSecretEnvVar{
+			{Name: "DB_PASSWORD", Key: "apps/db", Property: "password"},
+		},
+	}
+}
+
+func cappWithExternalSecrets(secrets ...cappv1alpha1.ExternalSecret) cappv1alpha1.Capp {
+	capp := newBaseCapp()
+	capp.Spec.SecretsSpec.Secrets = secrets
+	return capp
+}
+
+func getExternalSecret(t *testing.T, c client.Client, name string) *unstructured.Unstructured {
+	t.Helper()
+	externalSecret := NewExternalSecret()
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: cappNamespace}, externalSecret))
+	return externalSecret
+}
+
+func TestExternalSecretManagerPrepareSpec(t *testing.T) {
+	em := newExternalSecretManager(newFakeClient(newScheme()))
+
+	t.Run("defaults the refresh interval and the store kind", func(t *testing.T) {
+		spec := em.prepareSpec(cappWithExternalSecrets(), newExternalSecret())
+
+		require.Equal(t, externalSecretSpec{
+			RefreshInterval: "1h0m0s",
+			SecretStoreRef:  externalSecretStoreRef{Name: "vault", Kind: "SecretStore"},
+			Target:          externalSecretTarget{Name: syncedSecretName, CreationPolicy: "Owner"},
+			Data: []externalSecretDataTarget{{
+				SecretKey: "DB_PASSWORD",
+				RemoteRef: externalSecretRemoteRef{Key: "apps/db", Property: "password"},
+			}},
+		}, spec)
+	})
+
+	t.Run("sets the refresh interval and the store kind", func(t *testing.T) {
+		secret := newExternalSecret()
+		secret.RefreshInterval = &metav1.Duration{Duration: 5 * time.Minute}
+		secret.StoreRef.Kind = "ClusterSecretStore"
+
+		spec := em.prepareSpec(cappWithExternalSecrets(), secret)
+
+		require.Equal(t, "5m0s", spec.RefreshInterval)
+		require.Equal(t, "ClusterSecretStore", spec.SecretStoreRef.Kind)
+	})
+}
+
+func TestExternalSecretManagerCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates when not found", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(newExternalSecret())))
+
+		got := getExternalSecret(t, em.K8sClient, syncedSecretName)
+		require.Equal(t, cappName, got.GetOwnerReferences()[0].Name)
+		require.Equal(t, cappName, got.GetLabels()[cappmeta.CappResourceKey])
+		target, _, err := unstructured.NestedString(got.Object, "spec", "target", "name")
+		require.NoError(t, err)
+		require.Equal(t, syncedSecretName, target)
+	})
+
+	t.Run("ignores fields defaulted by the External Secrets Operator", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+		capp := cappWithExternalSecrets(newExternalSecret())
+		require.NoError(t, em.createOrUpdate(ctx, capp))
+
+		existing := getExternalSecret(t, em.K8sClient, syncedSecretName)
+		require.NoError(t, unstructured.SetNestedField(existing.Object, "Retain", "spec", "target", "deletionPolicy"))
+		existingSpec, err := appliedExternalSecretSpec(existing)
+		require.NoError(t, err)
+
+		desired, err := em.prepareResource(capp)
+		require.NoError(t, err)
+		require.NoError(t, ensureOwnerReference(em.K8sClient, &capp, desired[0], ExternalSecret))
+		needsApply, err := managedResourceNeedsApply(em.K8sClient, &capp, existing, desired[0],
+			existingSpec, em.prepareSpec(capp, capp.Spec.SecretsSpec.Secrets[0]))
+		require.NoError(t, err)
+		require.False(t, needsApply)
+	})
+
+	t.Run("updates when the keys change", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(newExternalSecret())))
+
+		secret := newExternalSecret()
+		secret.Env[0].Key = "apps/db-v2"
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(secret)))
+
+		got := getExternalSecret(t, em.K8sClient, syncedSecretName)
+		existingSpec, err := appliedExternalSecretSpec(got)
+		require.NoError(t, err)
+		require.Equal(t, "apps/db-v2", existingSpec.Data[0].RemoteRef.Key)
+	})
+
+	t.Run("deletes the external secrets of removed secrets", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+		stale := newExternalSecret()
+		stale.Name = "old"
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(stale)))
+
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(newExternalSecret())))
+
+		getErr := em.K8sClient.Get(ctx, types.NamespacedName{Name: cappName + "-old", Namespace: cappNamespace}, NewExternalSecret())
+		require.True(t, errors.IsNotFound(getErr))
+	})
+}
+
+func TestExternalSecretManagerCleanUp(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips delete when deleting and has owner reference", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(newExternalSecret())))
+
+		capp := cappWithDeletionTimestamp(cappWithExternalSecrets(newExternalSecret()))
+		existing := getExternalSecret(t, em.K8sClient, syncedSecretName)
+		ok, err := controllerutil.HasOwnerReference(existing.GetOwnerReferences(), &capp, newScheme())
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		require.NoError(t, em.CleanUp(ctx, capp))
+		getExternalSecret(t, em.K8sClient, syncedSecretName)
+	})
+
+	t.Run("deletes all when not required", func(t *testing.T) {
+		em := newExternalSecretManager(newFakeClient(newScheme()))
+		require.NoError(t, em.createOrUpdate(ctx, cappWithExternalSecrets(newExternalSecret())))
+
+		require.NoError(t, em.Manage(ctx, newBaseCapp()))
+
+		getErr := em.K8sClient.Get(ctx, types.NamespacedName{Name: syncedSecretName, Namespace: cappNamespace}, NewExternalSecret())
+		require.True(t, errors.IsNotFound(getErr))
+	})
+}
+
+func TestKnativeServiceManagerExternalSecretEnv(t *testing.T) {
+	ctx := context.Background()
+	km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+	capp := newKsvcCapp()
+	capp.Spec.ConfigurationSpec.Template.Spec.Containers = append(capp.Spec.ConfigurationSpec.Template.Spec.Containers,
+		corev1.Container{Name: "sidecar", Image: "sidecar:latest"})
+	secret := newExternalSecret()
+	secret.Env = append(secret.Env, cappv1alpha1.ExternalSecretEnvVar{Name: "DB_USER", Key: "apps/db", Property: "user", Containers: []string{"sidecar"}})
+	capp.Spec.SecretsSpec.Secrets = []cappv1alpha1.ExternalSecret{secret}
+
+	got := km.prepareResource(capp, ctx)
+
+	secretEnv := func(name string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: syncedSecretName},
+			Key:                  name,
+		}}}
+	}
+	containers := got.Spec.Template.Spec.Containers
+	require.Equal(t, []corev1.EnvVar{secretEnv("DB_PASSWORD")}, containers[0].Env)
+	require.Equal(t, []corev1.EnvVar{secretEnv("DB_PASSWORD"), secretEnv("DB_USER")}, containers[1].Env)
+}
@@ -0,0 +1,112 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TopologicalOrder returns the entries ordered so that every entry comes after its dependencies.
+// Entries whose dependencies are satisfied keep their declaration order, so the result is deterministic.
+// It fails if an entry depends on an unknown entry or if the dependencies form a cycle.
+func TopologicalOrder(entries []ResourceManagerEntry) ([]ResourceManagerEntry, error) {
+	index := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		if _, ok := index[entry.Name]; ok {
+			return nil, fmt.Errorf("duplicate resource manager %q", entry.Name)
+		}
+		index[entry.Name] = i
+	}
+
+	for _, entry := range entries {
+		for _, dep := range entry.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("resource manager %q depends on unknown resource manager %q", entry.Name, dep)
+			}
+		}
+	}
+
+	ordered := make([]ResourceManagerEntry, 0, len(entries))
+	placed := make([]bool, len(entries))
+	for len(ordered) < len(entries) {
+		progress := false
+		for i, entry := range entries {
+			if placed[i] || !dependenciesPlaced(entry, index, placed) {
+				continue
+			}
+			ordered = append(ordered, entry)
+			placed[i] = true
+			progress = true
+			break
+		}
+		if !progress {
+			var pending []string
+			for i, entry := range entries {
+				if !placed[i] {
+					pending = append(pending, entry.Name)
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between resource managers: %s", strings.Join(pending, ", "))
+		}
+	}
+
+	return ordered, nil
+}
+
+func dependenciesPlaced(entry ResourceManagerEntry, index map[string]int, placed []bool) bool {
+	for _, dep := range entry.DependsOn {
+		if !placed[index[dep]] {
+			return false
+		}
+	}
+	return true
+}
+
+// RunInDependencyOrder calls run for every entry, starting each entry once all of its dependencies have
+// finished, whether they failed or not, and running at most parallelism entries at a time.
+// The returned errors are in the declaration order of the entries, regardless of completion order.
+// If the dependency graph is invalid, run is never called and the graph error is returned.
+func RunInDependencyOrder(ctx context.Context, entries []ResourceManagerEntry, parallelism int, run func(ctx context.Context, entry ResourceManagerEntry) error) []error {
+	if _, err := TopologicalOrder(entries); err != nil {
+		return []error{err}
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	done := make(map[string]chan struct{}, len(entries))
+	for _, entry := range entries {
+		done[entry.Name] = make(chan struct{})
+	}
+
+	results := make([]error, len(entries))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[entry.Name])
+
+			for _, dep := range entry.DependsOn {
+				<-done[dep]
+			}
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = run(ctx, entry)
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, err := range results {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
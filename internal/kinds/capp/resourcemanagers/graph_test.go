@@ -0,0 +1,159 @@
+package resourcemanagers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func entryNames(entries []ResourceManagerEntry) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []ResourceManagerEntry
+		want      []string
+		expectErr string
+	}{
+		{
+			name:    "keeps declaration order without dependencies",
+			entries: []ResourceManagerEntry{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			want:    []string{"a", "b", "c"},
+		},
+		{
+			name: "places dependencies first",
+			entries: []ResourceManagerEntry{
+				{Name: DomainMapping, DependsOn: []string{Certificate}},
+				{Name: PingSource},
+				{Name: Certificate},
+			},
+			want: []string{PingSource, Certificate, DomainMapping},
+		},
+		{
+			name:      "fails on an unknown dependency",
+			entries:   []ResourceManagerEntry{{Name: "a", DependsOn: []string{"missing"}}},
+			expectErr: `depends on unknown resource manager "missing"`,
+		},
+		{
+			name:      "fails on duplicate names",
+			entries:   []ResourceManagerEntry{{Name: "a"}, {Name: "a"}},
+			expectErr: `duplicate resource manager "a"`,
+		},
+		{
+			name: "fails on a cycle",
+			entries: []ResourceManagerEntry{
+				{Name: "a"},
+				{Name: "b", DependsOn: []string{"c"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			expectErr: "dependency cycle between resource managers: b, c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordered, err := TopologicalOrder(tt.entries)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entryNames(ordered))
+		})
+	}
+}
+
+func TestRunInDependencyOrder(t *testing.T) {
+	t.Run("runs dependents after their dependencies", func(t *testing.T) {
+		entries := []ResourceManagerEntry{
+			{Name: KnativeService},
+			{Name: Certificate},
+			{Name: DomainMapping, DependsOn: []string{KnativeService, Certificate}},
+			{Name: PingSource, DependsOn: []string{KnativeService}},
+		}
+
+		var mu sync.Mutex
+		finished := map[string]bool{}
+		errs := RunInDependencyOrder(context.Background(), entries, 4, func(_ context.Context, entry ResourceManagerEntry) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, dep := range entry.DependsOn {
+				if !finished[dep] {
+					return errors.New(entry.Name + " ran before " + dep)
+				}
+			}
+			finished[entry.Name] = true
+			return nil
+		})
+
+		assert.Empty(t, errs)
+		assert.Len(t, finished, len(entries))
+	})
+
+	t.Run("never exceeds the parallelism", func(t *testing.T) {
+		entries := []ResourceManagerEntry{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+
+		var running, maxRunning atomic.Int32
+		errs := RunInDependencyOrder(context.Background(), entries, 2, func(context.Context, ResourceManagerEntry) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				seen := maxRunning.Load()
+				if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+
+		assert.Empty(t, errs)
+		assert.Equal(t, int32(2), maxRunning.Load())
+	})
+
+	t.Run("returns errors in declaration order and still runs dependents of failed entries", func(t *testing.T) {
+		entries := []ResourceManagerEntry{
+			{Name: "a"},
+			{Name: "b", DependsOn: []string{"c"}},
+			{Name: "c"},
+		}
+
+		var ran atomic.Int32
+		errs := RunInDependencyOrder(context.Background(), entries, 3, func(_ context.Context, entry ResourceManagerEntry) error {
+			ran.Add(1)
+			if entry.Name == "a" {
+				return nil
+			}
+			return errors.New(entry.Name)
+		})
+
+		assert.Equal(t, int32(3), ran.Load())
+		require.Len(t, errs, 2)
+		assert.EqualError(t, errs[0], "b")
+		assert.EqualError(t, errs[1], "c")
+	})
+
+	t.Run("returns the graph error without running anything", func(t *testing.T) {
+		entries := []ResourceManagerEntry{{Name: "a", DependsOn: []string{"a"}}}
+
+		errs := RunInDependencyOrder(context.Background(), entries, 1, func(context.Context, ResourceManagerEntry) error {
+			t.Fatal("run must not be called")
+			return nil
+		})
+
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "dependency cycle")
+	})
+}
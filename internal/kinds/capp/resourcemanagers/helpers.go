@@ -7,9 +7,9 @@ import (
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/dana-team/container-app-operator/internal/metrics"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,7 +26,7 @@ func ensureOwnerReference(k8s client.Client, capp *cappv1alpha1.Capp, obj client
 func createManagedResource(
 	ctx context.Context,
 	k8s client.Client,
-	create func(context.Context, client.Object) error,
+	apply func(context.Context, client.Object) error,
 	recorder events.EventRecorder,
 	capp *cappv1alpha1.Capp,
 	obj client.Object,
@@ -35,7 +35,10 @@ func createManagedResource(
 	if err := ensureOwnerReference(k8s, capp, obj, kind); err != nil {
 		return err
 	}
-	if err := create(ctx, obj); err != nil {
+	if err := setAppliedSpecHash(obj); err != nil {
+		return err
+	}
+	if err := apply(ctx, obj); err != nil {
 		recorder.Eventf(capp, nil, corev1.EventTypeWarning, eventFailed, eventFailed,
 			fmt.Sprintf("Failed to create %s %s", kind, obj.GetName()))
 		return err
@@ -45,16 +48,81 @@ func createManagedResource(
 	return nil
 }
 
-func managedResourceNeedsUpdate(origSpec, newSpec any, origOwners, newOwners []metav1.OwnerReference) bool {
-	return !equality.Semantic.DeepEqual(origSpec, newSpec) ||
-		!equality.Semantic.DeepEqual(origOwners, newOwners)
+// managedResourceNeedsApply reports whether existing differs from desired in the fields the operator
+// applies: the spec, the Capp owner reference, and the labels and annotations set on desired.
+// Labels, annotations and owner references added by others are ignored.
+func managedResourceNeedsApply(k8s client.Client, capp *cappv1alpha1.Capp, existing, desired client.Object, existingSpec, desiredSpec any) (bool, error) {
+	owned, err := controllerutil.HasOwnerReference(existing.GetOwnerReferences(), capp, k8s.Scheme())
+	if err != nil {
+		return false, err
+	}
+	return !owned ||
+		!equality.Semantic.DeepEqual(existingSpec, desiredSpec) ||
+		!isSubset(desired.GetLabels(), existing.GetLabels()) ||
+		!isSubset(desired.GetAnnotations(), existing.GetAnnotations()), nil
 }
 
-func updateManagedResourceIfNeeded(ctx context.Context, update func(context.Context, client.Object) error, obj client.Object, origSpec, newSpec any, origOwners []metav1.OwnerReference) error {
-	if !managedResourceNeedsUpdate(origSpec, newSpec, origOwners, obj.GetOwnerReferences()) {
-		return nil
+// applyManagedResourceIfNeeded sets the Capp owner reference and the applied spec hash on desired and
+// server-side applies it when managedResourceNeedsApply reports a difference with existing.
+func applyManagedResourceIfNeeded(
+	ctx context.Context,
+	k8s client.Client,
+	apply func(context.Context, client.Object) error,
+	recorder events.EventRecorder,
+	capp *cappv1alpha1.Capp,
+	existing, desired client.Object,
+	existingSpec, desiredSpec any,
+	kind string,
+) error {
+	if err := ensureOwnerReference(k8s, capp, desired, kind); err != nil {
+		return err
+	}
+	if err := setAppliedSpecHash(desired); err != nil {
+		return err
+	}
+	needsApply, err := managedResourceNeedsApply(k8s, capp, existing, desired, existingSpec, desiredSpec)
+	if err != nil || !needsApply {
+		return err
+	}
+	return applyManagedResource(ctx, apply, recorder, capp, existing, desired, kind)
+}
+
+// applyManagedResource server-side applies desired over existing. Out-of-band changes to the spec
+// fields last applied are reported with a DriftCorrected event before being reverted, or recorded
+// in the drift report of ctx and left in place when drift correction is paused for the Capp.
+func applyManagedResource(
+	ctx context.Context,
+	apply func(context.Context, client.Object) error,
+	recorder events.EventRecorder,
+	capp *cappv1alpha1.Capp,
+	existing, desired client.Object,
+	kind string,
+) error {
+	fields, err := driftedFields(existing, desired)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		drift := Drift{Kind: kind, Name: desired.GetName(), Fields: fields}
+		if DriftCorrectionPaused(*capp) {
+			metrics.RecordDrift(kind, metrics.DriftActionPaused)
+			driftReportFrom(ctx).record(drift)
+			return nil
+		}
+		metrics.RecordDrift(kind, metrics.DriftActionCorrected)
+		recorder.Eventf(capp, nil, corev1.EventTypeWarning, eventDriftCorrected, eventDriftCorrected,
+			fmt.Sprintf("Reverted out-of-band changes to %s", drift))
+	}
+	return apply(ctx, desired)
+}
+
+func isSubset(subset, set map[string]string) bool {
+	for key, value := range subset {
+		if actual, ok := set[key]; !ok || actual != value {
+			return false
+		}
 	}
-	return update(ctx, obj)
+	return true
 }
 
 func deleteOwnedResources[T client.Object](ctx context.Context, c client.Client, capp *cappv1alpha1.Capp, resources []T) error {
@@ -77,20 +77,14 @@ func (k KafkaSourceManager) createOrUpdate(ctx context.Context, capp cappv1alpha
 		if !errors.IsNotFound(err) {
 			return fmt.Errorf("failed to get KafkaSource %q: %w", desired.Name, err)
 		}
-		return createManagedResource(ctx, k.K8sClient, k.CreateResource, k.EventRecorder, &capp, &desired,
+		return createManagedResource(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, &desired,
 			KafkaSource, eventKafkaSourceCreated, eventKafkaSourceCreationFailed)
 	}
 
-	orig := existing.DeepCopy()
-	existing.Spec = *desired.Spec.DeepCopy()
-	existing.Spec.ConsumerGroup = orig.Spec.ConsumerGroup
-	if err := ensureOwnerReference(k.K8sClient, &capp, existing, KafkaSource); err != nil {
-		return err
-	}
-	if managedResourceNeedsUpdate(orig.Spec, existing.Spec, orig.OwnerReferences, existing.OwnerReferences) {
-		k.Log.Info("Updating KafkaSource", "Name", existing.Name)
-	}
-	return updateManagedResourceIfNeeded(ctx, k.UpdateResource, existing, orig.Spec, existing.Spec, orig.OwnerReferences)
+	// The consumer group is immutable once the KafkaSource exists, so keep applying the current value.
+	desired.Spec.ConsumerGroup = existing.Spec.ConsumerGroup
+	return applyManagedResourceIfNeeded(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, existing, &desired,
+		existing.Spec, desired.Spec, KafkaSource)
 }
 
 // prepareResource prepares a KafkaSource resource based on the provided Capp and source entry.
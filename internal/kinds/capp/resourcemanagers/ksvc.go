@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"path"
 	"slices"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	kautoscaling "knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +33,7 @@ const (
 	eventCappDisabled                     = "CappDisabled"
 	eventCappEnabled                      = "CappEnabled"
 	knativeServiceKind                    = "Service"
+	knativeVisibilityLabelKey             = "networking.knative.dev/visibility"
 
 	kubectlKubernetesIOAnnotationPrefix = "kubectl.kubernetes.io/"
 
@@ -77,11 +80,12 @@ func (k KnativeServiceManager) prepareResource(capp cappv1alpha1.Capp, ctx conte
 		},
 	}
 
-	// set defaults
+	if capp.Spec.RouteSpec.Visibility == cappv1alpha1.RouteVisibilityClusterLocal {
+		knativeService.Labels[knativeVisibilityLabelKey] = serving.VisibilityClusterLocal
+	}
+
+	// Knative defaults the remaining fields, which are left to it so that it keeps owning them.
 	knativeService.Spec.Template.Spec.EnableServiceLinks = new(bool)
-	knativeService.Spec.ConfigurationSpec.SetDefaults(ctx)
-	knativeService.Spec.RouteSpec.SetDefaults(ctx)
-	knativeService.Spec.Template.Spec.SetDefaults(ctx)
 	knativeService.Spec.Template.Spec.TimeoutSeconds = capp.Spec.RouteSpec.RouteTimeoutSeconds
 
 	for _, nfsVolume := range capp.Spec.VolumesSpec.NFSVolumes {
@@ -90,9 +94,63 @@ func (k KnativeServiceManager) prepareResource(capp cappv1alpha1.Capp, ctx conte
 			VolumeSource: corev1.VolumeSource{
 				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 					ClaimName: nfsVolume.Name,
+					ReadOnly:  NFSVolumeAccessMode(nfsVolume) == corev1.ReadOnlyMany,
 				},
 			},
 		})
+		addNFSVolumeMounts(knativeService.Spec.Template.Spec.Containers, nfsVolume)
+	}
+
+	for _, pvcVolume := range capp.Spec.VolumesSpec.PVCVolumes {
+		knativeService.Spec.Template.Spec.Volumes = append(knativeService.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: pvcVolume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: PVCClaimName(capp, pvcVolume),
+					ReadOnly:  pvcVolume.ReadOnly,
+				},
+			},
+		})
+	}
+
+	for _, configMapVolume := range capp.Spec.VolumesSpec.ConfigMapVolumes {
+		knativeService.Spec.Template.Spec.Volumes = append(knativeService.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: configMapVolume.Name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMapVolume.ConfigMapName},
+					Items:                configMapVolume.Items,
+				},
+			},
+		})
+	}
+
+	for _, secretVolume := range capp.Spec.VolumesSpec.SecretVolumes {
+		knativeService.Spec.Template.Spec.Volumes = append(knativeService.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: secretVolume.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretVolume.SecretName,
+					Items:      secretVolume.Items,
+				},
+			},
+		})
+	}
+
+	for _, secret := range capp.Spec.SecretsSpec.Secrets {
+		addExternalSecretEnv(knativeService.Spec.Template.Spec.Containers, ExternalSecretName(capp, secret), secret)
+	}
+
+	if capp.Spec.RouteSpec.Auth != nil {
+		addAuthProxy(&knativeService.Spec.Template.Spec.PodSpec, *capp.Spec.RouteSpec.Auth, k.CappConfig.Spec.AuthConfig)
+		// The webhook only hardens the containers of the Capp, so the injected sidecar is hardened here.
+		containers := knativeService.Spec.Template.Spec.Containers
+		proxyIndex := slices.IndexFunc(containers, func(container corev1.Container) bool {
+			return container.Name == AuthProxyContainerName
+		})
+		if proxyIndex >= 0 && !IsSecurityExempt(capp.Namespace, k.CappConfig.Spec.SecurityConfig) {
+			SetSecurityContextDefaults(&containers[proxyIndex], k.CappConfig.Spec.SecurityConfig)
+		}
 	}
 
 	knativeService.Spec.Template.Annotations = cappmeta.MergeMaps(knativeServiceAnnotations, setAutoScaler(capp, k.CappConfig.Spec.AutoscaleConfig))
@@ -101,6 +159,52 @@ func (k KnativeServiceManager) prepareResource(capp cappv1alpha1.Capp, ctx conte
 	return knativeService
 }
 
+// addNFSVolumeMounts adds the mounts declared on volume to the containers they are mounted in. A
+// container that already mounts something at the same path keeps its own mount, since Kubernetes
+// rejects duplicate mount paths.
+func addNFSVolumeMounts(containers []corev1.Container, volume cappv1alpha1.NFSVolume) {
+	readOnly := NFSVolumeAccessMode(volume) == corev1.ReadOnlyMany
+	for _, mount := range volume.Mounts {
+		for i := range containers {
+			if len(mount.Containers) > 0 && !slices.Contains(mount.Containers, containers[i].Name) {
+				continue
+			}
+			if slices.ContainsFunc(containers[i].VolumeMounts, func(existing corev1.VolumeMount) bool {
+				return path.Clean(existing.MountPath) == path.Clean(mount.MountPath)
+			}) {
+				continue
+			}
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      volume.Name,
+				MountPath: mount.MountPath,
+				SubPath:   mount.SubPath,
+				ReadOnly:  mount.ReadOnly || readOnly,
+			})
+		}
+	}
+}
+
+// addExternalSecretEnv sets the environment variables of secret, read from the Secret it is synced
+// into, in the containers they are set in.
+func addExternalSecretEnv(containers []corev1.Container, secretName string, secret cappv1alpha1.ExternalSecret) {
+	for _, env := range secret.Env {
+		for i := range containers {
+			if len(env.Containers) > 0 && !slices.Contains(env.Containers, containers[i].Name) {
+				continue
+			}
+			containers[i].Env = append(containers[i].Env, corev1.EnvVar{
+				Name: env.Name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  env.Name,
+					},
+				},
+			})
+		}
+	}
+}
+
 // CleanUp ensures the Knative Service is not left behind when it is no longer required for this Capp.
 func (k KnativeServiceManager) CleanUp(ctx context.Context, capp cappv1alpha1.Capp) error {
 	var ksvc knativev1.Service
@@ -141,11 +245,14 @@ func (k KnativeServiceManager) Manage(ctx context.Context, capp cappv1alpha1.Cap
 // createOrUpdate creates or updates a KSVC resource.
 func (k KnativeServiceManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Capp) error {
 	knativeServiceFromCapp := k.prepareResource(capp, ctx)
+	if err := k.setConfigHash(ctx, capp, &knativeServiceFromCapp); err != nil {
+		return err
+	}
 	knativeService := knativev1.Service{}
 
 	if err := k.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: capp.Name}, &knativeService); err != nil {
 		if errors.IsNotFound(err) {
-			if err := createManagedResource(ctx, k.K8sClient, k.CreateResource, k.EventRecorder, &capp, &knativeServiceFromCapp,
+			if err := createManagedResource(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, &knativeServiceFromCapp,
 				KnativeService, eventCappKnativeServiceCreated, eventCappKnativeServiceCreationFailed); err != nil {
 				return err
 			}
@@ -160,19 +267,16 @@ func (k KnativeServiceManager) createOrUpdate(ctx context.Context, capp cappv1al
 		return fmt.Errorf("failed to get KnativeService %q: %w", knativeService.Name, err)
 	}
 
-	orig := knativeService.DeepCopy()
-	knativeService.Spec = *knativeServiceFromCapp.Spec.DeepCopy()
-	if err := ensureOwnerReference(k.K8sClient, &capp, &knativeService, KnativeService); err != nil {
-		return err
-	}
-	if managedResourceNeedsUpdate(orig.Spec, knativeService.Spec, orig.OwnerReferences, knativeService.OwnerReferences) {
-		k.Log.V(1).Info("KnativeService spec or owner metadata differs from desired; applying update",
-			"knativeService", knativeService.Name,
-			"namespace", knativeService.Namespace,
-			"resourceVersion", knativeService.ResourceVersion,
-			"generation", knativeService.Generation)
-	}
-	return updateManagedResourceIfNeeded(ctx, k.UpdateResource, &knativeService, orig.Spec, knativeService.Spec, orig.OwnerReferences)
+	return applyManagedResourceIfNeeded(ctx, k.K8sClient, k.ApplyResource, k.EventRecorder, &capp, &knativeService, &knativeServiceFromCapp,
+		defaultedServiceSpec(ctx, knativeService.Spec), defaultedServiceSpec(ctx, knativeServiceFromCapp.Spec), KnativeService)
+}
+
+// defaultedServiceSpec returns spec with the defaults Knative sets on a KnativeService, so that the
+// desired spec, which leaves them to Knative, can be compared with the spec of the live KnativeService.
+func defaultedServiceSpec(ctx context.Context, spec knativev1.ServiceSpec) knativev1.ServiceSpec {
+	defaulted := *spec.DeepCopy()
+	defaulted.SetDefaults(ctx)
+	return defaulted
 }
 
 // setAutoScaler takes a Capp and returns autoscaler annotations based on the Capp's ScaleSpec.Metric value.
@@ -191,8 +295,15 @@ func setAutoScaler(capp cappv1alpha1.Capp, defaults cappv1alpha1.AutoscaleConfig
 		autoScaleAnnotations[kautoscaling.ScaleDownDelayAnnotationKey] = (time.Duration(*capp.Spec.ScaleSpec.ScaleDelaySeconds) * time.Second).String()
 	}
 
-	if capp.Spec.ScaleSpec.MinReplicas != nil {
-		autoScaleAnnotations[kautoscaling.MinScaleAnnotationKey] = fmt.Sprintf("%d", *capp.Spec.ScaleSpec.MinReplicas)
+	scaleToZeroAllowed := ScaleToZeroAllowed(capp, defaults)
+	minReplicas := capp.Spec.ScaleSpec.MinReplicas
+	if !scaleToZeroAllowed && (minReplicas == nil || *minReplicas < 1) {
+		warmReplicas := int32(1)
+		minReplicas = &warmReplicas
+	}
+
+	if minReplicas != nil {
+		autoScaleAnnotations[kautoscaling.MinScaleAnnotationKey] = fmt.Sprintf("%d", *minReplicas)
 	} else {
 		autoScaleAnnotations[kautoscaling.ActivationScaleKey] = fmt.Sprintf("%d", defaults.ActivationScale)
 	}
@@ -201,9 +312,28 @@ func setAutoScaler(capp cappv1alpha1.Capp, defaults cappv1alpha1.AutoscaleConfig
 		autoScaleAnnotations[kautoscaling.MaxScaleAnnotationKey] = fmt.Sprintf("%d", *capp.Spec.ScaleSpec.MaxReplicas)
 	}
 
+	if capp.Spec.ScaleSpec.InitialScale != nil {
+		autoScaleAnnotations[kautoscaling.InitialScaleAnnotationKey] = fmt.Sprintf("%d", *capp.Spec.ScaleSpec.InitialScale)
+	}
+
+	// The retention period only applies to the KPA, which is the only class able to scale to zero.
+	retention := capp.Spec.ScaleSpec.ScaleToZeroRetentionSeconds
+	if retention != nil && scaleToZeroAllowed && slices.Contains(kpaMetrics, scaleMetric) {
+		autoScaleAnnotations[kautoscaling.ScaleToZeroPodRetentionPeriodKey] = (time.Duration(*retention) * time.Second).String()
+	}
+
 	return autoScaleAnnotations
 }
 
+// ScaleToZeroAllowed returns whether the Capp may scale down to zero replicas, taking into
+// account both the Capp's ScaleSpec and the warm namespaces defined in the autoscale config.
+func ScaleToZeroAllowed(capp cappv1alpha1.Capp, autoscaleConfig cappv1alpha1.AutoscaleConfig) bool {
+	if slices.Contains(autoscaleConfig.WarmNamespaces, capp.Namespace) {
+		return false
+	}
+	return capp.Spec.ScaleSpec.AllowScaleToZero == nil || *capp.Spec.ScaleSpec.AllowScaleToZero
+}
+
 func getTargetValue(scaleMetric string, autoscale cappv1alpha1.AutoscaleConfig) string {
 	switch scaleMetric {
 	case rpsScaleKey:
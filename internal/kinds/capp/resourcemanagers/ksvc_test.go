@@ -119,6 +119,90 @@ func TestKnativeServiceManagerPrepareResource(t *testing.T) {
 		require.Equal(t, routeTimeout, *got.Spec.Template.Spec.TimeoutSeconds)
 	})
 
+	t.Run("leaves the fields Knative defaults unset", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+
+		got := km.prepareResource(newKsvcCapp(), ctx)
+
+		require.Nil(t, got.Spec.Template.Spec.ContainerConcurrency)
+		require.Nil(t, got.Spec.Template.Spec.Containers[0].ReadinessProbe)
+		require.Empty(t, got.Spec.Traffic)
+		require.NotNil(t, got.Spec.Template.Spec.EnableServiceLinks)
+		require.False(t, *got.Spec.Template.Spec.EnableServiceLinks)
+	})
+
+	t.Run("labels the service cluster-local only when the capp is", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+
+		require.NotContains(t, km.prepareResource(capp, ctx).Labels, knativeVisibilityLabelKey)
+
+		capp.Spec.RouteSpec.Visibility = cappv1alpha1.RouteVisibilityClusterLocal
+		require.Equal(t, "cluster-local", km.prepareResource(capp, ctx).Labels[knativeVisibilityLabelKey])
+	})
+
+	t.Run("routes requests through the oauth2-proxy sidecar when the capp requires a login", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 3000}}
+		capp.Spec.RouteSpec.Auth = &cappv1alpha1.RouteAuth{
+			IssuerURL:     "https://issuer.example.com",
+			ClientSecret:  "oidc-client",
+			AllowedGroups: []string{"admins"},
+		}
+
+		containers := km.prepareResource(capp, ctx).Spec.Template.Spec.Containers
+
+		require.Len(t, containers, 2)
+		require.Empty(t, containers[0].Ports)
+
+		proxy := containers[1]
+		require.Equal(t, AuthProxyContainerName, proxy.Name)
+		require.Equal(t, defaultAuthProxyImage, proxy.Image)
+		require.Equal(t, []corev1.ContainerPort{{ContainerPort: authProxyPort}}, proxy.Ports)
+		require.Contains(t, proxy.Args, "--oidc-issuer-url=https://issuer.example.com")
+		require.Contains(t, proxy.Args, "--upstream=http://127.0.0.1:3000")
+		require.Contains(t, proxy.Args, "--allowed-group=admins")
+		require.Len(t, proxy.Env, 3)
+		require.Equal(t, "oidc-client", proxy.Env[0].ValueFrom.SecretKeyRef.Name)
+		require.Equal(t, AuthClientIDKey, proxy.Env[0].ValueFrom.SecretKeyRef.Key)
+	})
+
+	t.Run("sets the security context defaults on the oauth2-proxy sidecar", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		km.CappConfig.Spec.SecurityConfig = cappv1alpha1.SecurityConfig{
+			RunAsNonRoot:           true,
+			DropAllCapabilities:    true,
+			ReadOnlyRootFilesystem: true,
+			SeccompRuntimeDefault:  true,
+		}
+		capp := newKsvcCapp()
+		capp.Spec.RouteSpec.Auth = &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: "oidc-client"}
+
+		securityContext := km.prepareResource(capp, ctx).Spec.Template.Spec.Containers[1].SecurityContext
+
+		require.NotNil(t, securityContext)
+		require.Equal(t, ptr.To(true), securityContext.RunAsNonRoot)
+		require.Equal(t, []corev1.Capability{"ALL"}, securityContext.Capabilities.Drop)
+		require.Equal(t, ptr.To(true), securityContext.ReadOnlyRootFilesystem)
+		require.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, securityContext.SeccompProfile.Type)
+
+		km.CappConfig.Spec.SecurityConfig.ExemptNamespaces = []string{cappNamespace}
+		require.Nil(t, km.prepareResource(capp, ctx).Spec.Template.Spec.Containers[1].SecurityContext)
+	})
+
+	t.Run("uses the oauth2-proxy image of the cappConfig", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		km.CappConfig.Spec.AuthConfig.ProxyImage = "registry.example.com/oauth2-proxy:v7"
+		capp := newKsvcCapp()
+		capp.Spec.RouteSpec.Auth = &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: "oidc-client"}
+
+		containers := km.prepareResource(capp, ctx).Spec.Template.Spec.Containers
+
+		require.Equal(t, "registry.example.com/oauth2-proxy:v7", containers[1].Image)
+		require.Contains(t, containers[1].Args, "--upstream=http://127.0.0.1:8080")
+	})
+
 	t.Run("appends nfs volumes as pvc volume sources", func(t *testing.T) {
 		const nfsVolumeName = "data-vol"
 
@@ -138,6 +222,70 @@ func TestKnativeServiceManagerPrepareResource(t *testing.T) {
 		require.Equal(t, nfsVolumeName, got.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
 	})
 
+	t.Run("adds the mounts of nfs volumes to the chosen containers", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = append(capp.Spec.ConfigurationSpec.Template.Spec.Containers,
+			corev1.Container{Name: "sidecar", Image: "sidecar:latest"})
+		capp.Spec.VolumesSpec.NFSVolumes = []cappv1alpha1.NFSVolume{{
+			Name:       "reports",
+			AccessMode: corev1.ReadOnlyMany,
+			Mounts: []cappv1alpha1.NFSVolumeMount{
+				{MountPath: "/reports", SubPath: "daily"},
+				{MountPath: "/archive", Containers: []string{"sidecar"}},
+			},
+		}}
+
+		got := km.prepareResource(capp, ctx)
+
+		require.True(t, got.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+		containers := got.Spec.Template.Spec.Containers
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "reports", MountPath: "/reports", SubPath: "daily", ReadOnly: true},
+		}, containers[0].VolumeMounts)
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "reports", MountPath: "/reports", SubPath: "daily", ReadOnly: true},
+			{Name: "reports", MountPath: "/archive", ReadOnly: true},
+		}, containers[1].VolumeMounts)
+	})
+
+	t.Run("keeps the mounts a container already declares at the path of an nfs mount", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+			{Name: "reports", MountPath: "/reports/"},
+		}
+		capp.Spec.VolumesSpec.NFSVolumes = []cappv1alpha1.NFSVolume{{
+			Name:   "reports",
+			Mounts: []cappv1alpha1.NFSVolumeMount{{MountPath: "/reports"}, {MountPath: "/archive"}},
+		}}
+
+		got := km.prepareResource(capp, ctx)
+
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "reports", MountPath: "/reports/"},
+			{Name: "reports", MountPath: "/archive"},
+		}, got.Spec.Template.Spec.Containers[0].VolumeMounts)
+	})
+
+	t.Run("appends pvc volumes with their claim names", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Spec.VolumesSpec.PVCVolumes = []cappv1alpha1.PVCVolume{
+			{Name: "shared", ClaimName: "existing-claim", ReadOnly: true},
+			{Name: "cache", Size: ptr.To(resource.MustParse("1Gi"))},
+		}
+
+		got := km.prepareResource(capp, ctx)
+
+		require.Len(t, got.Spec.Template.Spec.Volumes, 2)
+		require.Equal(t, "shared", got.Spec.Template.Spec.Volumes[0].Name)
+		require.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "existing-claim", ReadOnly: true},
+			got.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim)
+		require.Equal(t, "cache", got.Spec.Template.Spec.Volumes[1].Name)
+		require.Equal(t, cappName+"-cache", got.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	})
+
 	t.Run("merges autoscale annotations from capp and cappConfig", func(t *testing.T) {
 		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
 		capp := newKsvcCapp()
@@ -182,6 +330,87 @@ func TestKnativeServiceManagerManage(t *testing.T) {
 		require.Equal(t, updatedContainerImage, got.Spec.Template.Spec.Containers[0].Image)
 	})
 
+	t.Run("removes the cluster-local label when the capp becomes external", func(t *testing.T) {
+		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Spec.RouteSpec.Visibility = cappv1alpha1.RouteVisibilityClusterLocal
+		require.NoError(t, km.Manage(ctx, capp))
+
+		got := &knativev1.Service{}
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+		require.Equal(t, "cluster-local", got.Labels[knativeVisibilityLabelKey])
+
+		capp.Spec.RouteSpec.Visibility = cappv1alpha1.RouteVisibilityExternal
+		require.NoError(t, km.Manage(ctx, capp))
+
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+		require.NotContains(t, got.Labels, knativeVisibilityLabelKey)
+	})
+
+	t.Run("reverts drift in applied fields and keeps fields set by others", func(t *testing.T) {
+		const otherAnnotation = "example.com/managed-elsewhere"
+
+		km, recorder := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		require.NoError(t, km.Manage(ctx, capp))
+		require.Contains(t, <-recorder.Events, eventCappKnativeServiceCreated)
+
+		drifted := &knativev1.Service{}
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, drifted))
+		drifted.Annotations[otherAnnotation] = "true"
+		drifted.Spec.Template.Spec.Containers[0].Image = "example.com/app:drifted"
+		require.NoError(t, km.K8sClient.Update(ctx, drifted, client.FieldOwner("other-controller")))
+
+		require.NoError(t, km.Manage(ctx, capp))
+
+		got := &knativev1.Service{}
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+		require.Equal(t, "example.com/app:v1", got.Spec.Template.Spec.Containers[0].Image)
+		require.Equal(t, "true", got.Annotations[otherAnnotation])
+
+		event := <-recorder.Events
+		require.Contains(t, event, eventDriftCorrected)
+		require.Contains(t, event, `spec.template.spec.containers[0].image: "example.com/app:drifted" -> "example.com/app:v1"`)
+	})
+
+	t.Run("reports drift without reverting it when drift correction is paused", func(t *testing.T) {
+		km, recorder := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		capp.Annotations = map[string]string{cappmeta.DriftCorrectionAnnotationKey: cappmeta.DriftCorrectionPaused}
+		require.NoError(t, km.Manage(ctx, capp))
+		require.Contains(t, <-recorder.Events, eventCappKnativeServiceCreated)
+
+		drifted := &knativev1.Service{}
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, drifted))
+		drifted.Spec.Template.Spec.Containers[0].Image = "example.com/app:drifted"
+		require.NoError(t, km.K8sClient.Update(ctx, drifted))
+
+		report := &DriftReport{}
+		require.NoError(t, km.Manage(WithDriftReport(ctx, report), capp))
+
+		got := &knativev1.Service{}
+		require.NoError(t, km.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+		require.Equal(t, "example.com/app:drifted", got.Spec.Template.Spec.Containers[0].Image)
+		require.Empty(t, recorder.Events)
+
+		drifts := report.Drifts()
+		require.Len(t, drifts, 1)
+		require.Equal(t, KnativeService, drifts[0].Kind)
+		require.Equal(t, cappName, drifts[0].Name)
+	})
+
+	t.Run("does not report a Capp change as drift", func(t *testing.T) {
+		km, recorder := newKsvcManager(newFakeClient(newKsvcScheme()))
+		capp := newKsvcCapp()
+		require.NoError(t, km.Manage(ctx, capp))
+		require.Contains(t, <-recorder.Events, eventCappKnativeServiceCreated)
+
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Image = "example.com/app:v2"
+		require.NoError(t, km.Manage(ctx, capp))
+
+		require.Empty(t, recorder.Events)
+	})
+
 	t.Run("skips update when unchanged", func(t *testing.T) {
 		km, _ := newKsvcManager(newFakeClient(newKsvcScheme()))
 		capp := newKsvcCapp()
@@ -350,6 +579,47 @@ func TestSetAutoScaler(t *testing.T) {
 		require.Equal(t, "3", got[kautoscaling.ActivationScaleKey])
 	})
 
+	t.Run("sets initial-scale and retention for a scale-to-zero KPA Capp", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: cappName},
+			Spec: cappv1alpha1.CappSpec{ScaleSpec: cappv1alpha1.ScaleSpec{
+				Metric:                      kautoscaling.Concurrency,
+				InitialScale:                ptr.To(int32(2)),
+				ScaleToZeroRetentionSeconds: ptr.To(int32(90)),
+			}},
+		}
+		got := setAutoScaler(capp, defaults)
+		require.Equal(t, "2", got[kautoscaling.InitialScaleAnnotationKey])
+		require.Equal(t, "1m30s", got[kautoscaling.ScaleToZeroPodRetentionPeriodKey])
+		require.Equal(t, "3", got[kautoscaling.ActivationScaleKey])
+	})
+
+	t.Run("keeps one warm replica when scale to zero is disabled", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: cappName},
+			Spec: cappv1alpha1.CappSpec{ScaleSpec: cappv1alpha1.ScaleSpec{
+				Metric:                      kautoscaling.RPS,
+				AllowScaleToZero:            ptr.To(false),
+				ScaleToZeroRetentionSeconds: ptr.To(int32(90)),
+			}},
+		}
+		got := setAutoScaler(capp, defaults)
+		require.Equal(t, "1", got[kautoscaling.MinScaleAnnotationKey])
+		require.NotContains(t, got, kautoscaling.ActivationScaleKey)
+		require.NotContains(t, got, kautoscaling.ScaleToZeroPodRetentionPeriodKey)
+	})
+
+	t.Run("keeps one warm replica for Capps in warm namespaces", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: cappNamespace},
+			Spec:       cappv1alpha1.CappSpec{ScaleSpec: cappv1alpha1.ScaleSpec{Metric: kautoscaling.RPS, MinReplicas: ptr.To(int32(0))}},
+		}
+		warmDefaults := defaults
+		warmDefaults.WarmNamespaces = []string{cappNamespace}
+		got := setAutoScaler(capp, warmDefaults)
+		require.Equal(t, "1", got[kautoscaling.MinScaleAnnotationKey])
+	})
+
 	t.Run("returns empty map when metric is empty", func(t *testing.T) {
 		capp := cappv1alpha1.Capp{
 			ObjectMeta: metav1.ObjectMeta{Name: cappName},
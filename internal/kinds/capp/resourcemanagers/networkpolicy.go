@@ -0,0 +1,230 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	knativenetworking "knative.dev/serving/pkg/networking"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	NetworkPolicy                    = "NetworkPolicy"
+	eventNetworkPolicyCreationFailed = "NetworkPolicyCreationFailed"
+	eventNetworkPolicyCreated        = "NetworkPolicyCreated"
+	dnsPort                          = 53
+)
+
+// defaultNetworkSystemNamespaces are the namespaces of the Knative data plane when the CappConfig
+// does not set any.
+var defaultNetworkSystemNamespaces = []string{"knative-serving", "kourier-system"}
+
+type NetworkPolicyManager struct {
+	rclient.ResourceManagerClient
+	EventRecorder events.EventRecorder
+	CappConfig    *cappv1alpha1.CappConfig
+}
+
+// restrictsIngressPeers reports whether the NetworkPolicy of the Capp restricts the peers its ingress
+// is allowed from.
+func (n NetworkPolicyManager) restrictsIngressPeers(capp cappv1alpha1.Capp) bool {
+	return len(capp.Spec.NetworkSpec.Ingress) > 0 || n.CappConfig.Spec.NetworkConfig.DefaultDeny
+}
+
+// restrictsIngress reports whether the NetworkPolicy of the Capp restricts its ingress. The ingress of
+// a Capp requiring a login is always restricted to the ports of the Knative queue-proxy, so that its
+// containers cannot be reached on their own ports without going through the login sidecar.
+func (n NetworkPolicyManager) restrictsIngress(capp cappv1alpha1.Capp) bool {
+	return n.restrictsIngressPeers(capp) || capp.Spec.RouteSpec.Auth != nil
+}
+
+// restrictsEgress reports whether the NetworkPolicy of the Capp restricts its egress.
+func (n NetworkPolicyManager) restrictsEgress(capp cappv1alpha1.Capp) bool {
+	return len(capp.Spec.NetworkSpec.Egress) > 0 || n.CappConfig.Spec.NetworkConfig.DefaultDeny
+}
+
+// systemNamespacesPeer returns a peer selecting the namespaces of the Knative data plane.
+func (n NetworkPolicyManager) systemNamespacesPeer() networkingv1.NetworkPolicyPeer {
+	namespaces := n.CappConfig.Spec.NetworkConfig.SystemNamespaces
+	if len(namespaces) == 0 {
+		namespaces = defaultNetworkSystemNamespaces
+	}
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   namespaces,
+			}},
+		},
+	}
+}
+
+// namespacePeer returns a peer selecting the pods of the namespace, or only those of the Capp
+// named cappName in it if it is set.
+func namespacePeer(namespace, cappName string) networkingv1.NetworkPolicyPeer {
+	peer := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}},
+	}
+	if cappName != "" {
+		peer.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{cappmeta.CappResourceKey: cappName}}
+	}
+	return peer
+}
+
+// prepareIngressRules returns the ingress rules of the Capp, which always allow the Knative data plane
+// so that requests routed through the ingress gateways and the activator reach the Capp.
+func (n NetworkPolicyManager) prepareIngressRules(capp cappv1alpha1.Capp) []networkingv1.NetworkPolicyIngressRule {
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	if n.restrictsIngressPeers(capp) {
+		rule.From = []networkingv1.NetworkPolicyPeer{n.systemNamespacesPeer()}
+		for _, peer := range capp.Spec.NetworkSpec.Ingress {
+			namespace := peer.Namespace
+			if namespace == "" {
+				namespace = capp.Namespace
+			}
+			rule.From = append(rule.From, namespacePeer(namespace, peer.CappName))
+		}
+	}
+	if capp.Spec.RouteSpec.Auth != nil {
+		rule.Ports = queueProxyPorts()
+	}
+	return []networkingv1.NetworkPolicyIngressRule{rule}
+}
+
+// queueProxyPorts returns the ports the Knative queue-proxy of a Capp serves requests, probes and
+// metrics on.
+func queueProxyPorts() []networkingv1.NetworkPolicyPort {
+	ports := []int32{
+		knativenetworking.BackendHTTPPort,
+		knativenetworking.BackendHTTP2Port,
+		knativenetworking.BackendHTTPSPort,
+		knativenetworking.QueueAdminPort,
+		knativenetworking.AutoscalingQueueMetricsPort,
+		knativenetworking.UserQueueMetricsPort,
+	}
+	networkPolicyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		networkPolicyPorts = append(networkPolicyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		})
+	}
+	return networkPolicyPorts
+}
+
+// prepareEgressRules returns the egress rules of the Capp, which always allow DNS. Requests to other
+// Capps are routed through the Knative data plane, so it is allowed when the Capp may reach any.
+func (n NetworkPolicyManager) prepareEgressRules(capp cappv1alpha1.Capp) []networkingv1.NetworkPolicyEgressRule {
+	rules := []networkingv1.NetworkPolicyEgressRule{{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(dnsPort))},
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(dnsPort))},
+		},
+	}}
+	if len(capp.Spec.NetworkSpec.Egress) == 0 {
+		return rules
+	}
+
+	var to []networkingv1.NetworkPolicyPeer
+	reachesCapps := false
+	for _, peer := range capp.Spec.NetworkSpec.Egress {
+		if peer.CIDR != "" {
+			to = append(to, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: peer.CIDR}})
+			continue
+		}
+		namespace := peer.Namespace
+		if namespace == "" {
+			namespace = capp.Namespace
+		}
+		to = append(to, namespacePeer(namespace, peer.CappName))
+		reachesCapps = true
+	}
+	if reachesCapps {
+		to = append(to, n.systemNamespacesPeer())
+	}
+	return append(rules, networkingv1.NetworkPolicyEgressRule{To: to})
+}
+
+// prepareResource prepares the NetworkPolicy of the pods of the Capp.
+func (n NetworkPolicyManager) prepareResource(capp cappv1alpha1.Capp) networkingv1.NetworkPolicy {
+	networkPolicy := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capp.Name,
+			Namespace: capp.Namespace,
+			Labels:    cappmeta.ManagedResourceLabels(capp.Name),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{cappmeta.CappResourceKey: capp.Name}},
+		},
+	}
+
+	if n.restrictsIngress(capp) {
+		networkPolicy.Spec.PolicyTypes = append(networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+		networkPolicy.Spec.Ingress = n.prepareIngressRules(capp)
+	}
+	if n.restrictsEgress(capp) {
+		networkPolicy.Spec.PolicyTypes = append(networkPolicy.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		networkPolicy.Spec.Egress = n.prepareEgressRules(capp)
+	}
+
+	return networkPolicy
+}
+
+// CleanUp attempts to delete the associated NetworkPolicy for a given Capp resource.
+func (n NetworkPolicyManager) CleanUp(ctx context.Context, capp cappv1alpha1.Capp) error {
+	var networkPolicy networkingv1.NetworkPolicy
+	if err := n.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: capp.Name}, &networkPolicy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if capp.DeletionTimestamp != nil {
+		if ok, err := controllerutil.HasOwnerReference(networkPolicy.OwnerReferences, &capp, n.K8sClient.Scheme()); err != nil || ok {
+			return err
+		}
+	}
+	return client.IgnoreNotFound(n.DeleteResource(ctx, &networkPolicy))
+}
+
+// IsRequired is responsible to determine if resource NetworkPolicy is required.
+func (n NetworkPolicyManager) IsRequired(capp cappv1alpha1.Capp) bool {
+	return n.restrictsIngress(capp) || n.restrictsEgress(capp)
+}
+
+// Manage creates or updates a NetworkPolicy resource based on the provided Capp if it's required.
+// If it's not, then it cleans up the resource if it exists.
+func (n NetworkPolicyManager) Manage(ctx context.Context, capp cappv1alpha1.Capp) error {
+	if n.IsRequired(capp) {
+		return n.createOrUpdate(ctx, capp)
+	}
+
+	return n.CleanUp(ctx, capp)
+}
+
+// createOrUpdate creates or updates a NetworkPolicy resource.
+func (n NetworkPolicyManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Capp) error {
+	networkPolicyFromCapp := n.prepareResource(capp)
+	networkPolicy := networkingv1.NetworkPolicy{}
+
+	if err := n.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: networkPolicyFromCapp.Name}, &networkPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			return createManagedResource(ctx, n.K8sClient, n.ApplyResource, n.EventRecorder, &capp, &networkPolicyFromCapp,
+				NetworkPolicy, eventNetworkPolicyCreated, eventNetworkPolicyCreationFailed)
+		}
+		return fmt.Errorf("failed to get NetworkPolicy %q: %w", networkPolicyFromCapp.Name, err)
+	}
+
+	return applyManagedResourceIfNeeded(ctx, n.K8sClient, n.ApplyResource, n.EventRecorder, &capp, &networkPolicy, &networkPolicyFromCapp,
+		networkPolicy.Spec, networkPolicyFromCapp.Spec, NetworkPolicy)
+}
@@ -0,0 +1,201 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newNetworkPolicyScheme() *runtime.Scheme {
+	s := newScheme()
+	utilruntime.Must(networkingv1.AddToScheme(s))
+	return s
+}
+
+// newNetworkPolicyClient returns a fake client that deduces the schema of applied objects, since the
+// fake client fails to merge the typed schema of NetworkPolicies on apply.
+func newNetworkPolicyClient(objects ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(newNetworkPolicyScheme()).
+		WithTypeConverters(managedfields.NewDeducedTypeConverter()).
+		WithObjects(objects...).
+		Build()
+}
+
+func newNetworkPolicyManager(k8sClient client.Client, cappConfig *cappv1alpha1.CappConfig) NetworkPolicyManager {
+	return NetworkPolicyManager{
+		ResourceManagerClient: rclient.ResourceManagerClient{K8sClient: k8sClient, Log: logr.Discard()},
+		EventRecorder:         events.NewFakeRecorder(10),
+		CappConfig:            cappConfig,
+	}
+}
+
+func cappWithNetworkSpec(networkSpec cappv1alpha1.NetworkSpec) cappv1alpha1.Capp {
+	capp := newBaseCapp()
+	capp.Spec.NetworkSpec = networkSpec
+	return capp
+}
+
+func newManagedNetworkPolicy() *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cappName,
+			Namespace: cappNamespace,
+			Labels:    cappmeta.ManagedResourceLabels(cappName),
+		},
+	}
+}
+
+func TestNetworkPolicyManagerPrepareResource(t *testing.T) {
+	t.Run("restricts only ingress when only ingress is declared", func(t *testing.T) {
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(), newCappConfig())
+		capp := cappWithNetworkSpec(cappv1alpha1.NetworkSpec{
+			Ingress: []cappv1alpha1.NetworkIngressPeer{{Namespace: "frontend"}, {CappName: "gateway"}},
+		})
+
+		networkPolicy := nm.prepareResource(capp)
+
+		require.Equal(t, map[string]string{cappmeta.CappResourceKey: cappName}, networkPolicy.Spec.PodSelector.MatchLabels)
+		require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+		require.Empty(t, networkPolicy.Spec.Egress)
+
+		from := networkPolicy.Spec.Ingress[0].From
+		require.Len(t, from, 3)
+		require.Equal(t, defaultNetworkSystemNamespaces, from[0].NamespaceSelector.MatchExpressions[0].Values)
+		require.Equal(t, "frontend", from[1].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+		require.Nil(t, from[1].PodSelector)
+		require.Equal(t, cappNamespace, from[2].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+		require.Equal(t, "gateway", from[2].PodSelector.MatchLabels[cappmeta.CappResourceKey])
+	})
+
+	t.Run("allows dns and the data plane when egress reaches a capp", func(t *testing.T) {
+		cappConfig := newCappConfig()
+		cappConfig.Spec.NetworkConfig.SystemNamespaces = []string{"knative-serving", "istio-system"}
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(), cappConfig)
+		capp := cappWithNetworkSpec(cappv1alpha1.NetworkSpec{
+			Egress: []cappv1alpha1.NetworkEgressPeer{{CIDR: "10.0.0.0/16"}, {CappName: "db", Namespace: "data"}},
+		})
+
+		networkPolicy := nm.prepareResource(capp)
+
+		require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+		require.Len(t, networkPolicy.Spec.Egress, 2)
+		require.Len(t, networkPolicy.Spec.Egress[0].Ports, 2)
+		require.Empty(t, networkPolicy.Spec.Egress[0].To)
+
+		to := networkPolicy.Spec.Egress[1].To
+		require.Len(t, to, 3)
+		require.Equal(t, "10.0.0.0/16", to[0].IPBlock.CIDR)
+		require.Equal(t, "data", to[1].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+		require.Equal(t, "db", to[1].PodSelector.MatchLabels[cappmeta.CappResourceKey])
+		require.Equal(t, []string{"knative-serving", "istio-system"}, to[2].NamespaceSelector.MatchExpressions[0].Values)
+	})
+
+	t.Run("denies all but the data plane and dns by default", func(t *testing.T) {
+		cappConfig := newCappConfig()
+		cappConfig.Spec.NetworkConfig.DefaultDeny = true
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(), cappConfig)
+
+		networkPolicy := nm.prepareResource(newBaseCapp())
+
+		require.True(t, nm.IsRequired(newBaseCapp()))
+		require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+		require.Len(t, networkPolicy.Spec.Ingress[0].From, 1)
+		require.Len(t, networkPolicy.Spec.Egress, 1)
+	})
+
+	t.Run("restricts the ingress of a capp requiring a login to the queue-proxy ports", func(t *testing.T) {
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(), newCappConfig())
+		capp := newBaseCapp()
+		capp.Spec.RouteSpec.Auth = &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: "oidc-client"}
+
+		networkPolicy := nm.prepareResource(capp)
+
+		require.True(t, nm.IsRequired(capp))
+		require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, networkPolicy.Spec.PolicyTypes)
+		require.Len(t, networkPolicy.Spec.Ingress, 1)
+		require.Empty(t, networkPolicy.Spec.Ingress[0].From)
+		require.Equal(t, queueProxyPorts(), networkPolicy.Spec.Ingress[0].Ports)
+
+		capp.Spec.NetworkSpec.Ingress = []cappv1alpha1.NetworkIngressPeer{{Namespace: "frontend"}}
+		networkPolicy = nm.prepareResource(capp)
+		require.Len(t, networkPolicy.Spec.Ingress[0].From, 2)
+		require.Equal(t, queueProxyPorts(), networkPolicy.Spec.Ingress[0].Ports)
+	})
+}
+
+func TestNetworkPolicyManagerCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: cappName, Namespace: cappNamespace}
+	capp := cappWithNetworkSpec(cappv1alpha1.NetworkSpec{
+		Ingress: []cappv1alpha1.NetworkIngressPeer{{Namespace: "frontend"}},
+	})
+
+	t.Run("creates when not found", func(t *testing.T) {
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(), newCappConfig())
+
+		require.NoError(t, nm.createOrUpdate(ctx, capp))
+
+		got := &networkingv1.NetworkPolicy{}
+		require.NoError(t, nm.K8sClient.Get(ctx, key, got))
+		require.Equal(t, cappName, got.OwnerReferences[0].Name)
+		require.Len(t, got.Spec.Ingress[0].From, 2)
+	})
+
+	t.Run("updates when spec differs", func(t *testing.T) {
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(newManagedNetworkPolicy()), newCappConfig())
+
+		require.NoError(t, nm.createOrUpdate(ctx, capp))
+
+		got := &networkingv1.NetworkPolicy{}
+		require.NoError(t, nm.K8sClient.Get(ctx, key, got))
+		require.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, got.Spec.PolicyTypes)
+	})
+}
+
+func TestNetworkPolicyManagerManage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cleans up when not required", func(t *testing.T) {
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(newManagedNetworkPolicy()), newCappConfig())
+
+		require.NoError(t, nm.Manage(ctx, newBaseCapp()))
+
+		got := &networkingv1.NetworkPolicy{}
+		getErr := nm.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got)
+		require.True(t, errors.IsNotFound(getErr))
+	})
+}
+
+func TestNetworkPolicyManagerCleanUp(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips delete when deleting and has owner reference", func(t *testing.T) {
+		capp := cappWithDeletionTimestamp(newBaseCapp())
+		networkPolicy := newManagedNetworkPolicy()
+		require.NoError(t, controllerutil.SetOwnerReference(&capp, networkPolicy, newNetworkPolicyScheme()))
+
+		nm := newNetworkPolicyManager(newNetworkPolicyClient(networkPolicy), newCappConfig())
+		require.NoError(t, nm.CleanUp(ctx, capp))
+
+		got := &networkingv1.NetworkPolicy{}
+		require.NoError(t, nm.K8sClient.Get(ctx, types.NamespacedName{Name: cappName, Namespace: cappNamespace}, got))
+	})
+}
@@ -22,6 +22,8 @@ const (
 	NfsPvc                    = "NfsPvc"
 	eventNFSPVCCreationFailed = "NfsPvcCreationFailed"
 	eventNFSPVCCreated        = "NfsPvcCreated"
+	eventNFSPVCResized        = "NfsPvcResized"
+	eventNFSPVCResizeFailed   = "NfsPvcResizeFailed"
 )
 
 type NFSPVCManager struct {
@@ -37,17 +39,16 @@ func (n NFSPVCManager) prepareResource(capp cappv1alpha1.Capp) []nfspvcv1alpha1.
 	for _, nfsVolume := range capp.Spec.VolumesSpec.NFSVolumes {
 		nfsPvc := nfspvcv1alpha1.NfsPvc{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      nfsVolume.Name,
-				Namespace: capp.Namespace,
-				Labels:    cappmeta.ManagedResourceLabels(capp.Name),
+				Name:        nfsVolume.Name,
+				Namespace:   capp.Namespace,
+				Labels:      cappmeta.ManagedResourceLabels(capp.Name),
+				Annotations: reclaimPolicyAnnotations(nfsVolume.ReclaimPolicy),
 			},
 			Spec: nfspvcv1alpha1.NfsPvcSpec{
-				Server: nfsVolume.Server,
-				Path:   nfsVolume.Path,
-				AccessModes: []corev1.PersistentVolumeAccessMode{
-					corev1.ReadWriteMany, // We use ReadWriteMany as the access mode for NFS volumes, as they are shared across multiple pods(Knative revisions, autoscaler).
-				},
-				Capacity: nfsVolume.Capacity,
+				Server:      nfsVolume.Server,
+				Path:        nfsVolume.Path,
+				AccessModes: []corev1.PersistentVolumeAccessMode{NFSVolumeAccessMode(nfsVolume)},
+				Capacity:    nfsVolume.Capacity,
 			},
 		}
 		nfsPvcs = append(nfsPvcs, nfsPvc)
@@ -57,6 +58,15 @@ func (n NFSPVCManager) prepareResource(capp cappv1alpha1.Capp) []nfspvcv1alpha1.
 
 }
 
+// NFSVolumeAccessMode returns the access mode of the NfsPvc of volume. NFS volumes are ReadWriteMany
+// unless set otherwise, as they are shared across multiple pods (Knative revisions, autoscaler).
+func NFSVolumeAccessMode(volume cappv1alpha1.NFSVolume) corev1.PersistentVolumeAccessMode {
+	if volume.AccessMode == "" {
+		return corev1.ReadWriteMany
+	}
+	return volume.AccessMode
+}
+
 // getPreviousNFSPVCs returns a list of all NFSPVC objects that are related to the given Capp.
 func (n NFSPVCManager) getPreviousNFSPVCs(ctx context.Context, capp cappv1alpha1.Capp) (nfspvcv1alpha1.NfsPvcList, error) {
 	nfsPvcs := nfspvcv1alpha1.NfsPvcList{}
@@ -66,17 +76,28 @@ func (n NFSPVCManager) getPreviousNFSPVCs(ctx context.Context, capp cappv1alpha1
 	return nfsPvcs, nil
 }
 
-// CleanUp attempts to delete the associated NFSPVCs for a given Capp resource.
+// CleanUp attempts to reclaim the associated NFSPVCs for a given Capp resource that are no longer mounted
+// by any of its volumes, or all of them once the Capp is deleted. NFSPVCs whose reclaim policy is Retain
+// are orphaned instead of deleted.
 func (n NFSPVCManager) CleanUp(ctx context.Context, capp cappv1alpha1.Capp) error {
+	mounted := make(map[string]bool, len(capp.Spec.VolumesSpec.NFSVolumes))
+	if capp.DeletionTimestamp == nil {
+		for _, nfsVolume := range capp.Spec.VolumesSpec.NFSVolumes {
+			mounted[nfsVolume.Name] = true
+		}
+	}
+
 	nfsPvcs, err := n.getPreviousNFSPVCs(ctx, capp)
 	if err != nil {
 		return err
 	}
-	resources := make([]*nfspvcv1alpha1.NfsPvc, len(nfsPvcs.Items))
+	var resources []*nfspvcv1alpha1.NfsPvc
 	for i := range nfsPvcs.Items {
-		resources[i] = &nfsPvcs.Items[i]
+		if !mounted[nfsPvcs.Items[i].Name] {
+			resources = append(resources, &nfsPvcs.Items[i])
+		}
 	}
-	return deleteOwnedResources(ctx, n.K8sClient, &capp, resources)
+	return reclaimVolumes(ctx, n.K8sClient, &capp, resources)
 }
 
 // IsRequired is responsible to determine if resource NfsPvc is required.
@@ -94,7 +115,8 @@ func (n NFSPVCManager) Manage(ctx context.Context, capp cappv1alpha1.Capp) error
 	return n.CleanUp(ctx, capp)
 }
 
-// createOrUpdate creates or updates a NFSPVC resource.
+// createOrUpdate creates or updates a NFSPVC resource, adopting NFSPVCs retained from a former Capp,
+// and reclaims the NFSPVCs of volumes since removed from the Capp.
 func (n NFSPVCManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Capp) error {
 	generatedNFSPVCs := n.prepareResource(capp)
 
@@ -103,7 +125,7 @@ func (n NFSPVCManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Cap
 		existingNFSPVC := nfspvcv1alpha1.NfsPvc{}
 		if err := n.K8sClient.Get(ctx, client.ObjectKey{Namespace: nfspvc.Namespace, Name: nfspvc.Name}, &existingNFSPVC); err != nil {
 			if errors.IsNotFound(err) {
-				if err := createManagedResource(ctx, n.K8sClient, n.CreateResource, n.EventRecorder, &capp, nfspvc,
+				if err := createManagedResource(ctx, n.K8sClient, n.ApplyResource, n.EventRecorder, &capp, nfspvc,
 					NfsPvc, eventNFSPVCCreated, eventNFSPVCCreationFailed); err != nil {
 					return err
 				}
@@ -111,16 +133,58 @@ func (n NFSPVCManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Cap
 				return fmt.Errorf("failed to get NFSPVC %q: %w", nfspvc.Name, err)
 			}
 		} else {
-			orig := existingNFSPVC.DeepCopy()
-			existingNFSPVC.Spec = *nfspvc.Spec.DeepCopy()
-			if err := ensureOwnerReference(n.K8sClient, &capp, &existingNFSPVC, NfsPvc); err != nil {
+			if err := adoptVolume(ctx, n.K8sClient, &capp, &existingNFSPVC, NfsPvc); err != nil {
+				return err
+			}
+			// The capacity of an NfsPvc is immutable, so a resize is applied to its persistent volume instead.
+			capacity := nfspvc.Spec.Capacity
+			nfspvc.Spec.Capacity = existingNFSPVC.Spec.Capacity
+			if err := n.resize(ctx, capp, nfspvc, capacity); err != nil {
 				return err
 			}
-			if err := updateManagedResourceIfNeeded(ctx, n.UpdateResource, &existingNFSPVC, orig.Spec, existingNFSPVC.Spec, orig.OwnerReferences); err != nil {
+			if err := applyManagedResourceIfNeeded(ctx, n.K8sClient, n.ApplyResource, n.EventRecorder, &capp, &existingNFSPVC, nfspvc,
+				existingNFSPVC.Spec, nfspvc.Spec, NfsPvc); err != nil {
 				return err
 			}
 		}
 	}
 
+	return n.CleanUp(ctx, capp)
+}
+
+// NFSPersistentVolumeName returns the name of the PersistentVolume the nfspvc-operator provisions for
+// the NfsPvc with the given name and namespace.
+func NFSPersistentVolumeName(name, namespace string) string {
+	return fmt.Sprintf("%s-%s-pv", name, namespace)
+}
+
+// resize grows the PersistentVolume of nfspvc to capacity if it is smaller. A volume that is not
+// provisioned yet is left to the nfspvc-operator.
+func (n NFSPVCManager) resize(ctx context.Context, capp cappv1alpha1.Capp, nfspvc *nfspvcv1alpha1.NfsPvc, capacity corev1.ResourceList) error {
+	pv := corev1.PersistentVolume{}
+	if err := n.K8sClient.Get(ctx, client.ObjectKey{Name: NFSPersistentVolumeName(nfspvc.Name, nfspvc.Namespace)}, &pv); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PersistentVolume of NFSPVC %q: %w", nfspvc.Name, err)
+	}
+
+	requested, current := capacity[corev1.ResourceStorage], pv.Spec.Capacity[corev1.ResourceStorage]
+	if requested.Cmp(current) <= 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(pv.DeepCopy())
+	if pv.Spec.Capacity == nil {
+		pv.Spec.Capacity = corev1.ResourceList{}
+	}
+	pv.Spec.Capacity[corev1.ResourceStorage] = requested
+	if err := n.K8sClient.Patch(ctx, &pv, patch); err != nil {
+		n.EventRecorder.Eventf(&capp, nil, corev1.EventTypeWarning, eventNFSPVCResizeFailed, eventNFSPVCResizeFailed,
+			fmt.Sprintf("Failed to resize %s %s from %s to %s", NfsPvc, nfspvc.Name, current.String(), requested.String()))
+		return fmt.Errorf("failed to resize NFSPVC %q: %w", nfspvc.Name, err)
+	}
+	n.EventRecorder.Eventf(&capp, nil, corev1.EventTypeNormal, eventNFSPVCResized, eventNFSPVCResized,
+		fmt.Sprintf("Resized %s %s from %s to %s", NfsPvc, nfspvc.Name, current.String(), requested.String()))
 	return nil
 }
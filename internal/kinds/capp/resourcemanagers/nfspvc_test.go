@@ -76,6 +76,15 @@ func newNFSPVC() *nfspvcv1alpha1.NfsPvc {
 	}
 }
 
+func newNFSPersistentVolume(capacity string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: NFSPersistentVolumeName(nfsVolA, cappNamespace)},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+}
+
 func TestNFSPVCManagerCreateOrUpdate(t *testing.T) {
 	ctx := context.Background()
 	keyA := types.NamespacedName{Name: nfsVolA, Namespace: cappNamespace}
@@ -93,6 +102,18 @@ func TestNFSPVCManagerCreateOrUpdate(t *testing.T) {
 		require.Equal(t, cappName, got.OwnerReferences[0].Name)
 	})
 
+	t.Run("sets the access mode of the volume", func(t *testing.T) {
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme()))
+		volume := newNFSVolume(nfsVolA, nfsPath)
+		volume.AccessMode = corev1.ReadOnlyMany
+
+		require.NoError(t, nm.createOrUpdate(ctx, cappWithVolumes(volume)))
+
+		got := &nfspvcv1alpha1.NfsPvc{}
+		require.NoError(t, nm.K8sClient.Get(ctx, keyA, got))
+		require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}, got.Spec.AccessModes)
+	})
+
 	t.Run("updates when spec differs", func(t *testing.T) {
 		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme()))
 		existing := newNFSPVC()
@@ -106,6 +127,32 @@ func TestNFSPVCManagerCreateOrUpdate(t *testing.T) {
 		require.Equal(t, nfsPathV2, got.Spec.Path)
 	})
 
+	t.Run("resizes the persistent volume when the capacity grows", func(t *testing.T) {
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newNFSPVC(), newNFSPersistentVolume("1Gi")))
+		volume := newNFSVolume(nfsVolA, nfsPath)
+		volume.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")}
+
+		require.NoError(t, nm.createOrUpdate(ctx, cappWithVolumes(volume)))
+
+		pv := &corev1.PersistentVolume{}
+		require.NoError(t, nm.K8sClient.Get(ctx, types.NamespacedName{Name: NFSPersistentVolumeName(nfsVolA, cappNamespace)}, pv))
+		require.Equal(t, resource.MustParse("5Gi"), pv.Spec.Capacity[corev1.ResourceStorage])
+
+		got := &nfspvcv1alpha1.NfsPvc{}
+		require.NoError(t, nm.K8sClient.Get(ctx, keyA, got))
+		require.Equal(t, resource.MustParse("1Gi"), got.Spec.Capacity[corev1.ResourceStorage])
+	})
+
+	t.Run("leaves a persistent volume larger than the capacity", func(t *testing.T) {
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newNFSPVC(), newNFSPersistentVolume("10Gi")))
+
+		require.NoError(t, nm.createOrUpdate(ctx, cappWithVolumes(newNFSVolume(nfsVolA, nfsPath))))
+
+		pv := &corev1.PersistentVolume{}
+		require.NoError(t, nm.K8sClient.Get(ctx, types.NamespacedName{Name: NFSPersistentVolumeName(nfsVolA, cappNamespace)}, pv))
+		require.Equal(t, resource.MustParse("10Gi"), pv.Spec.Capacity[corev1.ResourceStorage])
+	})
+
 	t.Run("creates multiple NFSPVCs from spec", func(t *testing.T) {
 		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme()))
 		capp := cappWithVolumes(newNFSVolume(nfsVolA, nfsPath), newNFSVolume(nfsVolB, nfsPath))
@@ -74,19 +74,12 @@ func (p PingSourceManager) createOrUpdate(ctx context.Context, capp cappv1alpha1
 		if !errors.IsNotFound(err) {
 			return fmt.Errorf("failed to get PingSource %q: %w", desired.Name, err)
 		}
-		return createManagedResource(ctx, p.K8sClient, p.CreateResource, p.EventRecorder, &capp, &desired,
+		return createManagedResource(ctx, p.K8sClient, p.ApplyResource, p.EventRecorder, &capp, &desired,
 			PingSource, eventPingSourceCreated, eventPingSourceCreationFailed)
 	}
 
-	orig := existing.DeepCopy()
-	existing.Spec = *desired.Spec.DeepCopy()
-	if err := ensureOwnerReference(p.K8sClient, &capp, existing, PingSource); err != nil {
-		return err
-	}
-	if managedResourceNeedsUpdate(orig.Spec, existing.Spec, orig.OwnerReferences, existing.OwnerReferences) {
-		p.Log.Info("Updating PingSource", "Name", existing.Name)
-	}
-	return updateManagedResourceIfNeeded(ctx, p.UpdateResource, existing, orig.Spec, existing.Spec, orig.OwnerReferences)
+	return applyManagedResourceIfNeeded(ctx, p.K8sClient, p.ApplyResource, p.EventRecorder, &capp, existing, &desired,
+		existing.Spec, desired.Spec, PingSource)
 }
 
 // prepareResource prepares a PingSource resource based on the provided Capp and source entry.
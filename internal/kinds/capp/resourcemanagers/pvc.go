@@ -0,0 +1,171 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	PersistentVolumeClaim  = "PersistentVolumeClaim"
+	eventPVCCreationFailed = "PersistentVolumeClaimCreationFailed"
+	eventPVCCreated        = "PersistentVolumeClaimCreated"
+)
+
+type PVCManager struct {
+	rclient.ResourceManagerClient
+	EventRecorder events.EventRecorder
+}
+
+// PVCClaimName returns the name of the PersistentVolumeClaim mounted for volume: the claim it
+// references, or the one the operator creates for it.
+func PVCClaimName(capp cappv1alpha1.Capp, volume cappv1alpha1.PVCVolume) string {
+	if volume.ClaimName != "" {
+		return volume.ClaimName
+	}
+	return fmt.Sprintf("%s-%s", capp.Name, volume.Name)
+}
+
+// prepareResource prepares the PersistentVolumeClaims the operator creates for the PVC volumes of
+// the Capp that do not reference an existing claim.
+func (p PVCManager) prepareResource(capp cappv1alpha1.Capp) []corev1.PersistentVolumeClaim {
+	//nolint:prealloc
+	var pvcs []corev1.PersistentVolumeClaim
+
+	for _, volume := range capp.Spec.VolumesSpec.PVCVolumes {
+		if volume.Size == nil {
+			continue
+		}
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        PVCClaimName(capp, volume),
+				Namespace:   capp.Namespace,
+				Labels:      cappmeta.ManagedResourceLabels(capp.Name),
+				Annotations: reclaimPolicyAnnotations(volume.ReclaimPolicy),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{PVCVolumeAccessMode(volume)},
+				StorageClassName: volume.StorageClassName,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *volume.Size},
+				},
+			},
+		}
+		pvcs = append(pvcs, pvc)
+	}
+
+	return pvcs
+}
+
+// PVCVolumeAccessMode returns the access mode of the claim the operator creates for volume, which is
+// ReadWriteOnce unless set otherwise.
+func PVCVolumeAccessMode(volume cappv1alpha1.PVCVolume) corev1.PersistentVolumeAccessMode {
+	if volume.AccessMode == "" {
+		return corev1.ReadWriteOnce
+	}
+	return volume.AccessMode
+}
+
+// getPreviousPVCs returns a list of all PersistentVolumeClaims the operator created for the given Capp.
+func (p PVCManager) getPreviousPVCs(ctx context.Context, capp cappv1alpha1.Capp) (corev1.PersistentVolumeClaimList, error) {
+	pvcs := corev1.PersistentVolumeClaimList{}
+	if err := listManagedResources(ctx, p.K8sClient, capp, &pvcs, PersistentVolumeClaim, nil); err != nil {
+		return pvcs, err
+	}
+	return pvcs, nil
+}
+
+// CleanUp attempts to reclaim the PersistentVolumeClaims the operator created for a given Capp resource
+// that are no longer mounted by any of its PVC volumes, or all of them once the Capp is deleted. A created
+// claim that a volume now references by its claimName is kept, and claims whose reclaim policy is Retain
+// are orphaned instead of deleted.
+func (p PVCManager) CleanUp(ctx context.Context, capp cappv1alpha1.Capp) error {
+	mounted := make(map[string]bool, len(capp.Spec.VolumesSpec.PVCVolumes))
+	if capp.DeletionTimestamp == nil {
+		for _, volume := range capp.Spec.VolumesSpec.PVCVolumes {
+			mounted[PVCClaimName(capp, volume)] = true
+		}
+	}
+
+	pvcs, err := p.getPreviousPVCs(ctx, capp)
+	if err != nil {
+		return err
+	}
+	var resources []*corev1.PersistentVolumeClaim
+	for i := range pvcs.Items {
+		if !mounted[pvcs.Items[i].Name] {
+			resources = append(resources, &pvcs.Items[i])
+		}
+	}
+	return reclaimVolumes(ctx, p.K8sClient, &capp, resources)
+}
+
+// IsRequired is responsible to determine if the operator creates PersistentVolumeClaims for the Capp.
+func (p PVCManager) IsRequired(capp cappv1alpha1.Capp) bool {
+	for _, volume := range capp.Spec.VolumesSpec.PVCVolumes {
+		if volume.Size != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Manage creates or updates the PersistentVolumeClaims of the provided Capp if they are required.
+// If they are not, then it cleans up the claims it created before.
+func (p PVCManager) Manage(ctx context.Context, capp cappv1alpha1.Capp) error {
+	if p.IsRequired(capp) {
+		return p.createOrUpdate(ctx, capp)
+	}
+
+	return p.CleanUp(ctx, capp)
+}
+
+// createOrUpdate creates or updates the PersistentVolumeClaims of the Capp, adopting claims retained
+// from a former Capp of the same name, and reclaims those created for volumes since removed from it.
+func (p PVCManager) createOrUpdate(ctx context.Context, capp cappv1alpha1.Capp) error {
+	generatedPVCs := p.prepareResource(capp)
+	for i := range generatedPVCs {
+		pvc := &generatedPVCs[i]
+		existingPVC := corev1.PersistentVolumeClaim{}
+		if err := p.K8sClient.Get(ctx, client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}, &existingPVC); err != nil {
+			if errors.IsNotFound(err) {
+				if err := createManagedResource(ctx, p.K8sClient, p.ApplyResource, p.EventRecorder, &capp, pvc,
+					PersistentVolumeClaim, eventPVCCreated, eventPVCCreationFailed); err != nil {
+					return err
+				}
+				continue
+			}
+			return fmt.Errorf("failed to get PersistentVolumeClaim %q: %w", pvc.Name, err)
+		}
+		if err := adoptVolume(ctx, p.K8sClient, &capp, &existingPVC, PersistentVolumeClaim); err != nil {
+			return err
+		}
+		if err := applyManagedResourceIfNeeded(ctx, p.K8sClient, p.ApplyResource, p.EventRecorder, &capp, &existingPVC, pvc,
+			appliedPVCSpec(existingPVC.Spec, pvc.Spec), pvc.Spec, PersistentVolumeClaim); err != nil {
+			return err
+		}
+	}
+
+	return p.CleanUp(ctx, capp)
+}
+
+// appliedPVCSpec returns the fields of spec the operator sets on the claims it creates, leaving out
+// those the API server and the volume binder fill in, so that it can be compared with desired.
+func appliedPVCSpec(spec, desired corev1.PersistentVolumeClaimSpec) corev1.PersistentVolumeClaimSpec {
+	applied := corev1.PersistentVolumeClaimSpec{
+		AccessModes: spec.AccessModes,
+		Resources:   corev1.VolumeResourceRequirements{Requests: spec.Resources.Requests},
+	}
+	if desired.StorageClassName != nil {
+		applied.StorageClassName = spec.StorageClassName
+	}
+	return applied
+}
@@ -0,0 +1,197 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	pvcVolume     = "cache"
+	pvcClaimName  = cappName + "-" + pvcVolume
+	existingClaim = "existing-claim"
+)
+
+func newPVCManager(k8sClient client.Client) PVCManager {
+	return PVCManager{
+		ResourceManagerClient: rclient.ResourceManagerClient{K8sClient: k8sClient, Log: logr.Discard()},
+		EventRecorder:         events.NewFakeRecorder(10),
+	}
+}
+
+func cappWithPVCVolumes(vols ...cappv1alpha1.PVCVolume) cappv1alpha1.Capp {
+	capp := newBaseCapp()
+	capp.Spec.VolumesSpec.PVCVolumes = vols
+	return capp
+}
+
+func newSizedPVCVolume(size string) cappv1alpha1.PVCVolume {
+	return cappv1alpha1.PVCVolume{Name: pvcVolume, Size: ptr.To(resource.MustParse(size))}
+}
+
+func newManagedPVC(name string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cappNamespace,
+			Labels:      cappmeta.ManagedResourceLabels(cappName),
+			Annotations: reclaimPolicyAnnotations(""),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+}
+
+func TestPVCManagerPrepareResource(t *testing.T) {
+	pm := newPVCManager(newFakeClient(newScheme()))
+
+	t.Run("skips volumes referencing an existing claim", func(t *testing.T) {
+		capp := cappWithPVCVolumes(cappv1alpha1.PVCVolume{Name: pvcVolume, ClaimName: existingClaim})
+		require.Empty(t, pm.prepareResource(capp))
+		require.False(t, pm.IsRequired(capp))
+	})
+
+	t.Run("defaults the access mode to ReadWriteOnce", func(t *testing.T) {
+		pvcs := pm.prepareResource(cappWithPVCVolumes(newSizedPVCVolume("1Gi")))
+
+		require.Len(t, pvcs, 1)
+		require.Equal(t, pvcClaimName, pvcs[0].Name)
+		require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvcs[0].Spec.AccessModes)
+		require.Nil(t, pvcs[0].Spec.StorageClassName)
+	})
+
+	t.Run("sets the storage class, access mode and size", func(t *testing.T) {
+		volume := newSizedPVCVolume("5Gi")
+		volume.StorageClassName = ptr.To("fast")
+		volume.AccessMode = corev1.ReadWriteMany
+
+		pvcs := pm.prepareResource(cappWithPVCVolumes(volume))
+
+		require.Len(t, pvcs, 1)
+		require.Equal(t, ptr.To("fast"), pvcs[0].Spec.StorageClassName)
+		require.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvcs[0].Spec.AccessModes)
+		require.Equal(t, resource.MustParse("5Gi"), pvcs[0].Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+}
+
+func TestPVCManagerCreateOrUpdate(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: pvcClaimName, Namespace: cappNamespace}
+
+	t.Run("creates when not found", func(t *testing.T) {
+		pm := newPVCManager(newFakeClient(newScheme()))
+
+		require.NoError(t, pm.createOrUpdate(ctx, cappWithPVCVolumes(newSizedPVCVolume("1Gi"))))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, key, got))
+		require.Equal(t, cappName, got.OwnerReferences[0].Name)
+	})
+
+	t.Run("expands the claim when the size grows", func(t *testing.T) {
+		pm := newPVCManager(newFakeClient(newScheme(), newManagedPVC(pvcClaimName)))
+
+		require.NoError(t, pm.createOrUpdate(ctx, cappWithPVCVolumes(newSizedPVCVolume("2Gi"))))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, key, got))
+		require.Equal(t, resource.MustParse("2Gi"), got.Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+
+	t.Run("ignores fields set by the binder", func(t *testing.T) {
+		existing := newManagedPVC(pvcClaimName)
+		existing.Spec.VolumeName = "pv-1"
+		existing.Spec.StorageClassName = ptr.To("standard")
+		existing.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+		pm := newPVCManager(newFakeClient(newScheme(), existing))
+		capp := cappWithPVCVolumes(newSizedPVCVolume("1Gi"))
+		require.NoError(t, controllerutil.SetOwnerReference(&capp, existing, newScheme()))
+
+		desired := pm.prepareResource(capp)[0]
+		require.NoError(t, ensureOwnerReference(pm.K8sClient, &capp, &desired, PersistentVolumeClaim))
+		needsApply, err := managedResourceNeedsApply(pm.K8sClient, &capp, existing, &desired,
+			appliedPVCSpec(existing.Spec, desired.Spec), desired.Spec)
+		require.NoError(t, err)
+		require.False(t, needsApply)
+	})
+
+	t.Run("deletes claims of removed volumes", func(t *testing.T) {
+		stale := newManagedPVC(cappName + "-old")
+		pm := newPVCManager(newFakeClient(newScheme(), stale))
+
+		require.NoError(t, pm.createOrUpdate(ctx, cappWithPVCVolumes(newSizedPVCVolume("1Gi"))))
+
+		got := &corev1.PersistentVolumeClaim{}
+		getErr := pm.K8sClient.Get(ctx, client.ObjectKeyFromObject(stale), got)
+		require.True(t, errors.IsNotFound(getErr), "expected %q to not exist", stale.Name)
+	})
+}
+
+func TestPVCManagerManage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cleans up when not required", func(t *testing.T) {
+		pm := newPVCManager(newFakeClient(newScheme(), newManagedPVC(pvcClaimName)))
+
+		require.NoError(t, pm.Manage(ctx, newBaseCapp()))
+
+		got := &corev1.PersistentVolumeClaim{}
+		getErr := pm.K8sClient.Get(ctx, types.NamespacedName{Name: pvcClaimName, Namespace: cappNamespace}, got)
+		require.True(t, errors.IsNotFound(getErr))
+	})
+
+	t.Run("keeps a created claim now referenced by claimName", func(t *testing.T) {
+		pm := newPVCManager(newFakeClient(newScheme(), newManagedPVC(pvcClaimName)))
+		capp := cappWithPVCVolumes(cappv1alpha1.PVCVolume{Name: pvcVolume, ClaimName: pvcClaimName})
+
+		require.NoError(t, pm.Manage(ctx, capp))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, types.NamespacedName{Name: pvcClaimName, Namespace: cappNamespace}, got))
+	})
+}
+
+func TestPVCManagerCleanUp(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips delete when deleting and has owner reference", func(t *testing.T) {
+		capp := cappWithDeletionTimestamp(newBaseCapp())
+		pvc := newManagedPVC(pvcClaimName)
+		require.NoError(t, controllerutil.SetOwnerReference(&capp, pvc, newScheme()))
+
+		pm := newPVCManager(newFakeClient(newScheme(), pvc))
+		require.NoError(t, pm.CleanUp(ctx, capp))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, types.NamespacedName{Name: pvcClaimName, Namespace: cappNamespace}, got))
+	})
+
+	t.Run("leaves claims the operator did not create", func(t *testing.T) {
+		unmanaged := newManagedPVC(existingClaim)
+		unmanaged.Labels = nil
+		pm := newPVCManager(newFakeClient(newScheme(), unmanaged))
+
+		require.NoError(t, pm.CleanUp(ctx, newBaseCapp()))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, types.NamespacedName{Name: existingClaim, Namespace: cappNamespace}, got))
+	})
+}
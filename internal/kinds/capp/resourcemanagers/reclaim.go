@@ -0,0 +1,97 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reclaimPolicyAnnotations returns the annotations recording policy on a volume created for a Capp.
+func reclaimPolicyAnnotations(policy cappv1alpha1.VolumeReclaimPolicy) map[string]string {
+	if policy == "" {
+		policy = cappv1alpha1.VolumeReclaimPolicyDelete
+	}
+	return map[string]string{cappmeta.ReclaimPolicyAnnotationKey: string(policy)}
+}
+
+// isRetained reports whether the reclaim policy recorded on volume is Retain.
+func isRetained(volume client.Object) bool {
+	return volume.GetAnnotations()[cappmeta.ReclaimPolicyAnnotationKey] == string(cappv1alpha1.VolumeReclaimPolicyRetain)
+}
+
+// reclaimVolumes deletes the volumes created for capp, or orphans those whose reclaim policy is
+// Retain so that they outlive it.
+func reclaimVolumes[T client.Object](ctx context.Context, c client.Client, capp *cappv1alpha1.Capp, volumes []T) error {
+	var toDelete []T
+	for _, volume := range volumes {
+		if !isRetained(volume) {
+			toDelete = append(toDelete, volume)
+			continue
+		}
+		if err := orphanVolume(ctx, c, capp, volume); err != nil {
+			return err
+		}
+	}
+	return deleteOwnedResources(ctx, c, capp, toDelete)
+}
+
+// orphanVolume detaches volume from capp by removing its owner reference and the labels marking it as
+// managed for capp, and records the name of capp on it.
+func orphanVolume(ctx context.Context, c client.Client, capp *cappv1alpha1.Capp, volume client.Object) error {
+	base, ok := volume.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to copy volume %q", volume.GetName())
+	}
+
+	var ownerReferences []metav1.OwnerReference
+	for _, ownerReference := range volume.GetOwnerReferences() {
+		if ownerReference.UID != capp.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	volume.SetOwnerReferences(ownerReferences)
+
+	labels := volume.GetLabels()
+	delete(labels, cappmeta.CappResourceKey)
+	delete(labels, cappmeta.ManagedByLabelKey)
+	volume.SetLabels(labels)
+
+	annotations := volume.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[cappmeta.RetainedFromAnnotationKey] = capp.Name
+	volume.SetAnnotations(annotations)
+
+	if err := c.Patch(ctx, volume, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to retain volume %q: %w", volume.GetName(), err)
+	}
+	return nil
+}
+
+// adoptVolume prepares an existing volume to be applied for capp. A volume retained from a former Capp
+// is adopted, while one managed for another Capp is refused.
+func adoptVolume(ctx context.Context, c client.Client, capp *cappv1alpha1.Capp, volume client.Object, kind string) error {
+	if owner, ok := volume.GetLabels()[cappmeta.CappResourceKey]; ok && owner != capp.Name {
+		return fmt.Errorf("%s %q is used by Capp %q", kind, volume.GetName(), owner)
+	}
+	if _, retained := volume.GetAnnotations()[cappmeta.RetainedFromAnnotationKey]; !retained {
+		return nil
+	}
+
+	base, ok := volume.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("failed to copy %s %q", kind, volume.GetName())
+	}
+	annotations := volume.GetAnnotations()
+	delete(annotations, cappmeta.RetainedFromAnnotationKey)
+	volume.SetAnnotations(annotations)
+	if err := c.Patch(ctx, volume, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to adopt %s %q: %w", kind, volume.GetName(), err)
+	}
+	return nil
+}
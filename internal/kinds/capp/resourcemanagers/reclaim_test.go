@@ -0,0 +1,112 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newRetainedNFSPVC(capp cappv1alpha1.Capp) *nfspvcv1alpha1.NfsPvc {
+	nfspvc := newNFSPVC()
+	nfspvc.Annotations = reclaimPolicyAnnotations(cappv1alpha1.VolumeReclaimPolicyRetain)
+	if err := controllerutil.SetOwnerReference(&capp, nfspvc, newNFSPVCScheme()); err != nil {
+		panic(err)
+	}
+	return nfspvc
+}
+
+func TestReclaimVolumes(t *testing.T) {
+	ctx := context.Background()
+	keyA := types.NamespacedName{Name: nfsVolA, Namespace: cappNamespace}
+
+	t.Run("orphans a retained volume removed from the capp", func(t *testing.T) {
+		capp := newBaseCapp()
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newRetainedNFSPVC(capp)))
+
+		require.NoError(t, nm.Manage(ctx, capp))
+
+		got := &nfspvcv1alpha1.NfsPvc{}
+		require.NoError(t, nm.K8sClient.Get(ctx, keyA, got))
+		require.Empty(t, got.OwnerReferences)
+		require.NotContains(t, got.Labels, cappmeta.CappResourceKey)
+		require.NotContains(t, got.Labels, cappmeta.ManagedByLabelKey)
+		require.Equal(t, cappName, got.Annotations[cappmeta.RetainedFromAnnotationKey])
+	})
+
+	t.Run("orphans a retained volume when the capp is deleted", func(t *testing.T) {
+		capp := cappWithDeletionTimestamp(cappWithVolumes(newNFSVolume(nfsVolA, nfsPath)))
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newRetainedNFSPVC(capp)))
+
+		require.NoError(t, nm.CleanUp(ctx, capp))
+
+		got := &nfspvcv1alpha1.NfsPvc{}
+		require.NoError(t, nm.K8sClient.Get(ctx, keyA, got))
+		require.Empty(t, got.OwnerReferences)
+	})
+
+	t.Run("deletes a volume removed from the capp by default", func(t *testing.T) {
+		kept := newNFSPVC()
+		kept.Name = nfsVolB
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newNFSPVC(), kept))
+
+		require.NoError(t, nm.Manage(ctx, cappWithVolumes(newNFSVolume(nfsVolB, nfsPath))))
+
+		getErr := nm.K8sClient.Get(ctx, keyA, &nfspvcv1alpha1.NfsPvc{})
+		require.True(t, errors.IsNotFound(getErr))
+		require.NoError(t, nm.K8sClient.Get(ctx, types.NamespacedName{Name: nfsVolB, Namespace: cappNamespace}, &nfspvcv1alpha1.NfsPvc{}))
+	})
+}
+
+func TestAdoptVolume(t *testing.T) {
+	ctx := context.Background()
+	keyA := types.NamespacedName{Name: nfsVolA, Namespace: cappNamespace}
+
+	t.Run("adopts a volume retained from a former capp", func(t *testing.T) {
+		former := newBaseCapp()
+		former.UID = "former-uid"
+		retained := newRetainedNFSPVC(former)
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), retained))
+		require.NoError(t, nm.CleanUp(ctx, cappWithDeletionTimestamp(former)))
+
+		capp := cappWithVolumes(newNFSVolume(nfsVolA, nfsPath))
+		capp.Name = "new-capp"
+		require.NoError(t, nm.Manage(ctx, capp))
+
+		got := &nfspvcv1alpha1.NfsPvc{}
+		require.NoError(t, nm.K8sClient.Get(ctx, keyA, got))
+		require.Len(t, got.OwnerReferences, 1)
+		require.Equal(t, capp.UID, got.OwnerReferences[0].UID)
+		require.Equal(t, "new-capp", got.Labels[cappmeta.CappResourceKey])
+		require.NotContains(t, got.Annotations, cappmeta.RetainedFromAnnotationKey)
+	})
+
+	t.Run("refuses a volume used by another capp", func(t *testing.T) {
+		nm := newNFSPVCManager(newFakeClient(newNFSPVCScheme(), newNFSPVC()))
+
+		capp := cappWithVolumes(newNFSVolume(nfsVolA, nfsPath))
+		capp.Name = "new-capp"
+		require.ErrorContains(t, nm.Manage(ctx, capp), `is used by Capp "my-capp"`)
+	})
+
+	t.Run("adopts a retained claim into a capp of the same name", func(t *testing.T) {
+		retained := newManagedPVC(pvcClaimName)
+		retained.Annotations = map[string]string{cappmeta.RetainedFromAnnotationKey: cappName}
+		retained.Labels = nil
+		pm := newPVCManager(newFakeClient(newScheme(), retained))
+
+		require.NoError(t, pm.Manage(ctx, cappWithPVCVolumes(newSizedPVCVolume("1Gi"))))
+
+		got := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, pm.K8sClient.Get(ctx, types.NamespacedName{Name: pvcClaimName, Namespace: cappNamespace}, got))
+		require.Equal(t, cappName, got.Labels[cappmeta.CappResourceKey])
+		require.NotContains(t, got.Annotations, cappmeta.RetainedFromAnnotationKey)
+	})
+}
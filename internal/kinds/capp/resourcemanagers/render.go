@@ -0,0 +1,127 @@
+package resourcemanagers
+
+import (
+	"context"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rclient "github.com/dana-team/container-app-operator/internal/kinds/capp/resourceclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// RenderResources returns the child resources the resource managers would apply for capp under cappConfig,
+// in the order the managers are declared, without contacting a cluster. The resources are rendered in their
+// steady state, in which the TLS secret issued for the Certificate already exists. They carry their
+// apiVersion and kind, taken from scheme, but no owner reference, since that needs the UID the API server
+// assigns to the Capp.
+func RenderResources(ctx context.Context, scheme *runtime.Scheme, capp cappv1alpha1.Capp, cappConfig *cappv1alpha1.CappConfig) ([]client.Object, error) {
+	var rendered []client.Object
+
+	rmClient := rclient.ResourceManagerClient{K8sClient: fake.NewClientBuilder().WithScheme(scheme).Build(), Log: logr.Discard()}
+	ksvcManager := KnativeServiceManager{ResourceManagerClient: rmClient, CappConfig: cappConfig}
+	if ksvcManager.IsRequired(capp) {
+		ksvc := ksvcManager.prepareResource(capp, ctx)
+		rendered = append(rendered, &ksvc)
+	}
+
+	nfspvcManager := NFSPVCManager{ResourceManagerClient: rmClient}
+	if nfspvcManager.IsRequired(capp) {
+		for _, nfspvc := range nfspvcManager.prepareResource(capp) {
+			rendered = append(rendered, &nfspvc)
+		}
+	}
+
+	pvcManager := PVCManager{ResourceManagerClient: rmClient}
+	if pvcManager.IsRequired(capp) {
+		for _, pvc := range pvcManager.prepareResource(capp) {
+			rendered = append(rendered, &pvc)
+		}
+	}
+
+	externalSecretManager := ExternalSecretManager{ResourceManagerClient: rmClient}
+	if externalSecretManager.IsRequired(capp) {
+		externalSecrets, err := externalSecretManager.prepareResource(capp)
+		if err != nil {
+			return nil, err
+		}
+		for _, externalSecret := range externalSecrets {
+			rendered = append(rendered, externalSecret)
+		}
+	}
+
+	networkPolicyManager := NetworkPolicyManager{ResourceManagerClient: rmClient, CappConfig: cappConfig}
+	if networkPolicyManager.IsRequired(capp) {
+		networkPolicy := networkPolicyManager.prepareResource(capp)
+		rendered = append(rendered, &networkPolicy)
+	}
+
+	outputManager := SyslogNGOutputManager{ResourceManagerClient: rmClient}
+	if outputManager.IsRequired(capp) {
+		output := outputManager.prepareResource(capp)
+		rendered = append(rendered, &output)
+	}
+
+	flowManager := SyslogNGFlowManager{ResourceManagerClient: rmClient}
+	if flowManager.IsRequired(capp) {
+		flow := flowManager.prepareResource(capp)
+		rendered = append(rendered, &flow)
+	}
+
+	certificateManager := CertificateManager{ResourceManagerClient: rmClient, CappConfig: cappConfig}
+	if certificateManager.IsRequired(capp) {
+		certificate := certificateManager.prepareResource(capp)
+		rendered = append(rendered, &certificate)
+
+		tlsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: certificate.Spec.SecretName, Namespace: certificate.Namespace}}
+		rmClient.K8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(tlsSecret).Build()
+	}
+
+	domainMappingManager := DomainMappingManager{ResourceManagerClient: rmClient, CappConfig: cappConfig}
+	if domainMappingManager.IsRequired(capp) {
+		domainMapping, err := domainMappingManager.prepareResource(ctx, capp)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, &domainMapping)
+	}
+
+	dnsRecordManager := DNSRecordManager{ResourceManagerClient: rmClient, CappConfig: cappConfig}
+	if dnsRecordManager.IsRequired(capp) {
+		dnsRecord := dnsRecordManager.prepareResource(capp)
+		rendered = append(rendered, &dnsRecord)
+	}
+
+	pingSourceManager := PingSourceManager{ResourceManagerClient: rmClient}
+	kafkaSourceManager := KafkaSourceManager{ResourceManagerClient: rmClient}
+	for _, source := range capp.Spec.EventSourcesSpec.Sources {
+		if source.PingSourceConfiguration != nil {
+			pingSource := pingSourceManager.prepareResource(capp, source)
+			rendered = append(rendered, &pingSource)
+		}
+	}
+	for _, source := range capp.Spec.EventSourcesSpec.Sources {
+		if source.KafkaSourceConfiguration != nil {
+			kafkaSource := kafkaSourceManager.prepareResource(capp, source)
+			rendered = append(rendered, &kafkaSource)
+		}
+	}
+
+	for _, obj := range rendered {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", obj.GetName(), err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		if err := setAppliedSpecHash(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,130 @@
+package resourcemanagers
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	dnsrecordv1alpha1 "github.com/dana-team/provider-dns-v2/apis/namespaced/record/v1alpha1"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	kafkasourcev1 "knative.dev/eventing-kafka-broker/control-plane/pkg/apis/sources/v1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
+	knativev1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRenderScheme() *runtime.Scheme {
+	s := newScheme()
+	utilruntime.Must(knativev1.AddToScheme(s))
+	utilruntime.Must(knativev1beta1.AddToScheme(s))
+	utilruntime.Must(cmapi.AddToScheme(s))
+	utilruntime.Must(dnsrecordv1alpha1.AddToScheme(s))
+	utilruntime.Must(sourcesv1.AddToScheme(s))
+	utilruntime.Must(kafkasourcev1.AddToScheme(s))
+	utilruntime.Must(networkingv1.AddToScheme(s))
+	return s
+}
+
+func renderedKinds(objects []client.Object) []string {
+	kinds := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		kinds = append(kinds, obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return kinds
+}
+
+func TestRenderResources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("renders only the knative service for a plain capp", func(t *testing.T) {
+		capp := newBaseCapp()
+		capp.Spec.State = cappv1alpha1.CappStateEnabled
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, newCappConfig())
+		require.NoError(t, err)
+		require.Equal(t, []string{"Service"}, renderedKinds(rendered))
+		require.Equal(t, knativev1.SchemeGroupVersion.String(), rendered[0].GetObjectKind().GroupVersionKind().GroupVersion().String())
+		require.Equal(t, cappName, rendered[0].GetName())
+		require.NotEmpty(t, rendered[0].GetAnnotations()[cappmeta.AppliedSpecHashAnnotationKey])
+		require.Empty(t, rendered[0].GetOwnerReferences())
+	})
+
+	t.Run("renders route and event source resources in manager order", func(t *testing.T) {
+		capp := newCappWithTLS(hostnameBare, true)
+		capp.Spec.State = cappv1alpha1.CappStateEnabled
+		capp.Spec.EventSourcesSpec.Sources = []cappv1alpha1.SourceConfiguration{
+			newKafkaSourceEntry(ordersSource, newKafkaSourceConfiguration()),
+			newPingSourceEntry(sourceA, cappv1alpha1.PingSourceConfiguration{Schedule: schedule}),
+		}
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, newCappConfigWithDNS())
+		require.NoError(t, err)
+		require.Equal(t, []string{"Service", "Certificate", "DomainMapping", "CNAMERecord", "PingSource", "KafkaSource"}, renderedKinds(rendered))
+	})
+
+	t.Run("renders the claims of pvc volumes", func(t *testing.T) {
+		capp := newBaseCapp()
+		capp.Spec.State = cappv1alpha1.CappStateEnabled
+		capp.Spec.VolumesSpec.PVCVolumes = []cappv1alpha1.PVCVolume{
+			{Name: "shared", ClaimName: "existing-claim"},
+			{Name: "cache", Size: ptr.To(resource.MustParse("1Gi"))},
+		}
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, newCappConfig())
+		require.NoError(t, err)
+		require.Equal(t, []string{"Service", "PersistentVolumeClaim"}, renderedKinds(rendered))
+		require.Equal(t, cappName+"-cache", rendered[1].GetName())
+	})
+
+	t.Run("renders the external secrets", func(t *testing.T) {
+		capp := newBaseCapp()
+		capp.Spec.State = cappv1alpha1.CappStateEnabled
+		capp.Spec.SecretsSpec.Secrets = []cappv1alpha1.ExternalSecret{newExternalSecret()}
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, newCappConfig())
+		require.NoError(t, err)
+		require.Equal(t, []string{"Service", "ExternalSecret"}, renderedKinds(rendered))
+		require.Equal(t, ExternalSecretGVK, rendered[1].GetObjectKind().GroupVersionKind())
+	})
+
+	t.Run("renders the network policy when default deny is enabled", func(t *testing.T) {
+		capp := newBaseCapp()
+		capp.Spec.State = cappv1alpha1.CappStateEnabled
+		cappConfig := newCappConfig()
+		cappConfig.Spec.NetworkConfig.DefaultDeny = true
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, cappConfig)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Service", "NetworkPolicy"}, renderedKinds(rendered))
+	})
+
+	t.Run("renders the domain mapping with the certificate secret", func(t *testing.T) {
+		capp := newCappWithTLS(hostnameBare, true)
+
+		rendered, err := RenderResources(ctx, newRenderScheme(), capp, newCappConfigWithDNS())
+		require.NoError(t, err)
+		require.Len(t, rendered, 3)
+
+		certificate, ok := rendered[0].(*cmapi.Certificate)
+		require.True(t, ok)
+		domainMapping, ok := rendered[1].(*knativev1beta1.DomainMapping)
+		require.True(t, ok)
+		require.NotNil(t, domainMapping.Spec.TLS)
+		require.Equal(t, certificate.Spec.SecretName, domainMapping.Spec.TLS.SecretName)
+	})
+
+	t.Run("fails for a kind missing from the scheme", func(t *testing.T) {
+		capp := newCappWithHostname(hostnameBare)
+
+		_, err := RenderResources(ctx, newScheme(), capp, newCappConfigWithDNS())
+		require.Error(t, err)
+	})
+}
@@ -18,6 +18,9 @@ type ResourceManager interface {
 type ResourceManagerEntry struct {
 	Name    string
 	Manager ResourceManager
+	// DependsOn lists the names of the entries that must be managed before this one.
+	// Entries without dependencies between them may be managed concurrently.
+	DependsOn []string
 }
 
 // ManagerMap converts an ordered slice of entries into a map keyed by name,
@@ -0,0 +1,45 @@
+package resourcemanagers
+
+import (
+	"slices"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// IsSecurityExempt reports whether Capps in the namespace are exempt from the security config.
+func IsSecurityExempt(namespace string, securityConfig cappv1alpha1.SecurityConfig) bool {
+	return slices.Contains(securityConfig.ExemptNamespaces, namespace)
+}
+
+// SetSecurityContextDefaults sets the security context defaults of the security config on the container
+// if they are not already set.
+func SetSecurityContextDefaults(container *corev1.Container, securityConfig cappv1alpha1.SecurityConfig) {
+	if !securityConfig.RunAsNonRoot && !securityConfig.DropAllCapabilities &&
+		!securityConfig.ReadOnlyRootFilesystem && !securityConfig.SeccompRuntimeDefault {
+		return
+	}
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+
+	if securityConfig.RunAsNonRoot && securityContext.RunAsNonRoot == nil {
+		securityContext.RunAsNonRoot = ptr.To(true)
+	}
+	if securityConfig.DropAllCapabilities {
+		if securityContext.Capabilities == nil {
+			securityContext.Capabilities = &corev1.Capabilities{}
+		}
+		if len(securityContext.Capabilities.Drop) == 0 {
+			securityContext.Capabilities.Drop = []corev1.Capability{"ALL"}
+		}
+	}
+	if securityConfig.ReadOnlyRootFilesystem && securityContext.ReadOnlyRootFilesystem == nil {
+		securityContext.ReadOnlyRootFilesystem = ptr.To(true)
+	}
+	if securityConfig.SeccompRuntimeDefault && securityContext.SeccompProfile == nil {
+		securityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+}
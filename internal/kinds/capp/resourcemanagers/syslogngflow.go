@@ -92,16 +92,12 @@ func (f SyslogNGFlowManager) createOrUpdate(ctx context.Context, capp cappv1alph
 
 	if err := f.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: syslogNGFlowFromCapp.Name}, &syslogNGFlow); err != nil {
 		if errors.IsNotFound(err) {
-			return createManagedResource(ctx, f.K8sClient, f.CreateResource, f.EventRecorder, &capp, &syslogNGFlowFromCapp,
+			return createManagedResource(ctx, f.K8sClient, f.ApplyResource, f.EventRecorder, &capp, &syslogNGFlowFromCapp,
 				SyslogNGFlow, eventCappSyslogNGFlowCreated, eventCappSyslogNGFlowCreationFailed)
 		}
 		return fmt.Errorf("failed to get SyslogNGFlow %q: %w", syslogNGFlow.Name, err)
 	}
 
-	orig := syslogNGFlow.DeepCopy()
-	syslogNGFlow.Spec = *syslogNGFlowFromCapp.Spec.DeepCopy()
-	if err := ensureOwnerReference(f.K8sClient, &capp, &syslogNGFlow, SyslogNGFlow); err != nil {
-		return err
-	}
-	return updateManagedResourceIfNeeded(ctx, f.UpdateResource, &syslogNGFlow, orig.Spec, syslogNGFlow.Spec, orig.OwnerReferences)
+	return applyManagedResourceIfNeeded(ctx, f.K8sClient, f.ApplyResource, f.EventRecorder, &capp, &syslogNGFlow, &syslogNGFlowFromCapp,
+		syslogNGFlow.Spec, syslogNGFlowFromCapp.Spec, SyslogNGFlow)
 }
@@ -158,16 +158,12 @@ func (o SyslogNGOutputManager) createOrUpdate(ctx context.Context, capp cappv1al
 
 	if err := o.K8sClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: syslogNGOutputFromCapp.Name}, &syslogNGOutput); err != nil {
 		if errors.IsNotFound(err) {
-			return createManagedResource(ctx, o.K8sClient, o.CreateResource, o.EventRecorder, &capp, &syslogNGOutputFromCapp,
+			return createManagedResource(ctx, o.K8sClient, o.ApplyResource, o.EventRecorder, &capp, &syslogNGOutputFromCapp,
 				SyslogNGOutput, eventCappSyslogNGOutputCreated, eventCappSyslogNGOutputCreationFailed)
 		}
 		return fmt.Errorf("failed to get SyslogNGOutput %q: %w", syslogNGOutputFromCapp.Name, err)
 	}
 
-	orig := syslogNGOutput.DeepCopy()
-	syslogNGOutput.Spec = *syslogNGOutputFromCapp.Spec.DeepCopy()
-	if err := ensureOwnerReference(o.K8sClient, &capp, &syslogNGOutput, SyslogNGOutput); err != nil {
-		return err
-	}
-	return updateManagedResourceIfNeeded(ctx, o.UpdateResource, &syslogNGOutput, orig.Spec, syslogNGOutput.Spec, orig.OwnerReferences)
+	return applyManagedResourceIfNeeded(ctx, o.K8sClient, o.ApplyResource, o.EventRecorder, &capp, &syslogNGOutput, &syslogNGOutputFromCapp,
+		syslogNGOutput.Spec, syslogNGOutputFromCapp.Spec, SyslogNGOutput)
 }
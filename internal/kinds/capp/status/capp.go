@@ -5,6 +5,7 @@ import (
 
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
 	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	"github.com/dana-team/container-app-operator/internal/tracing"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/go-logr/logr"
@@ -31,7 +32,10 @@ func CreateStateStatus(stateStatus *cappv1alpha1.StateStatus, cappStateFromSpec
 }
 
 // SyncStatus updates the Capp status subresource from the observed state of its managed resources.
-func SyncStatus(ctx context.Context, capp cappv1alpha1.Capp, log logr.Logger, r client.Client, resourceManagers map[string]rmanagers.ResourceManager, cappConfig *cappv1alpha1.CappConfig, syncErrors []error) error {
+func SyncStatus(ctx context.Context, capp cappv1alpha1.Capp, log logr.Logger, r client.Client, resourceManagers map[string]rmanagers.ResourceManager, cappConfig *cappv1alpha1.CappConfig, appliedOverrides []string, syncErrors []error, drifts []rmanagers.Drift) (err error) {
+	ctx, span := tracing.Start(ctx, "SyncStatus", tracing.CappAttributes(capp.Namespace, capp.Name)...)
+	defer func() { tracing.End(span, err) }()
+
 	cappObject := cappv1alpha1.Capp{}
 	if err := r.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: capp.Name}, &cappObject); err != nil {
 		return err
@@ -64,6 +68,7 @@ func SyncStatus(ctx context.Context, capp cappv1alpha1.Capp, log logr.Logger, r
 	if err != nil {
 		return err
 	}
+	routeStatus.InternalURL = internalURL(knativeObjectStatus)
 	cappObject.Status.RouteStatus = routeStatus
 
 	nfspvcManager := resourceManagers[rmanagers.NfsPvc]
@@ -79,9 +84,28 @@ func SyncStatus(ctx context.Context, capp cappv1alpha1.Capp, log logr.Logger, r
 	}
 	cappObject.Status.EventingStatus = eventingStatus
 
+	secretsStatus, err := buildSecretsStatus(ctx, r, capp)
+	if err != nil {
+		return err
+	}
+	cappObject.Status.SecretsStatus = secretsStatus
+
+	cappObject.Status.ConfigStatus = buildConfigStatus(cappConfig, appliedOverrides)
+
 	CreateStateStatus(&cappObject.Status.StateStatus, capp.Spec.State)
 
 	buildCappConditions(&cappObject.Status, capp, resourceManagers, syncErrors)
+	meta.SetStatusCondition(&cappObject.Status.Conditions, buildPolicyCompliantCondition(capp, cappConfig))
+	if capp.Spec.Paused {
+		meta.SetStatusCondition(&cappObject.Status.Conditions, buildPausedCondition())
+	} else {
+		meta.RemoveStatusCondition(&cappObject.Status.Conditions, cappv1alpha1.CappConditionPaused)
+	}
+	if rmanagers.DriftCorrectionPaused(capp) {
+		meta.SetStatusCondition(&cappObject.Status.Conditions, buildDriftedCondition(drifts))
+	} else {
+		meta.RemoveStatusCondition(&cappObject.Status.Conditions, cappv1alpha1.CappConditionDrifted)
+	}
 
 	if equality.Semantic.DeepEqual(
 		stripVolatileStatusFields(*oldStatus),
@@ -99,6 +123,15 @@ func SyncStatus(ctx context.Context, capp cappv1alpha1.Capp, log logr.Logger, r
 	return nil
 }
 
+// buildConfigStatus records the effective CappConfig spec and the overrides merged into it.
+func buildConfigStatus(cappConfig *cappv1alpha1.CappConfig, appliedOverrides []string) cappv1alpha1.ConfigStatus {
+	configStatus := cappv1alpha1.ConfigStatus{EffectiveConfig: cappConfig.Spec.DeepCopy()}
+	if len(appliedOverrides) > 0 {
+		configStatus.AppliedOverrides = appliedOverrides
+	}
+	return configStatus
+}
+
 // buildCappConditions derives top-level Capp conditions from the collected sub-statuses.
 func buildCappConditions(status *cappv1alpha1.CappStatus, capp cappv1alpha1.Capp, resourceManagers map[string]rmanagers.ResourceManager, syncErrors []error) {
 	condition := computeReadyCondition(status, capp, resourceManagers, syncErrors)
@@ -130,12 +163,16 @@ func computeReadyCondition(status *cappv1alpha1.CappStatus, capp cappv1alpha1.Ca
 		}
 	}
 
-	if resourceManagers[rmanagers.NfsPvc].IsRequired(capp) {
+	if resourceManagers[rmanagers.NfsPvc].IsRequired(capp) || len(capp.Spec.VolumesSpec.PVCVolumes) > 0 {
 		if reason, msg, ok := volumesNotReady(status.VolumesStatus); !ok {
 			return readyFalse(reason, msg)
 		}
 	}
 
+	if reason, msg, ok := secretsNotReady(status.SecretsStatus); !ok {
+		return readyFalse(reason, msg)
+	}
+
 	if resourceManagers[rmanagers.PingSource].IsRequired(capp) ||
 		resourceManagers[rmanagers.KafkaSource].IsRequired(capp) {
 		if reason, msg, ok := eventingNotReady(status.EventingStatus); !ok {
@@ -209,6 +246,22 @@ func volumesNotReady(vs cappv1alpha1.VolumesStatus) (string, string, bool) {
 				"NFS volume " + v.VolumeName + " is not bound", false
 		}
 	}
+	for _, v := range vs.PVCVolumesStatus {
+		if v.Phase != corev1.ClaimBound {
+			return cappv1alpha1.CappReadyReasonVolumesNotReady,
+				"PVC volume " + v.VolumeName + " is not bound", false
+		}
+	}
+	return "", "", true
+}
+
+func secretsNotReady(secrets []cappv1alpha1.ExternalSecretStatus) (string, string, bool) {
+	for _, s := range secrets {
+		if !s.Synced {
+			return cappv1alpha1.CappReadyReasonSecretsNotReady,
+				"secret " + s.Name + " has not been synced yet", false
+		}
+	}
 	return "", "", true
 }
 
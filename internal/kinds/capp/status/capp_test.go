@@ -127,12 +127,21 @@ func nfsVolumesUnbound(name string) cappv1alpha1.VolumesStatus {
 	}
 }
 
+func pvcVolumesStatus(name string, phase corev1.PersistentVolumeClaimPhase) cappv1alpha1.VolumesStatus {
+	return cappv1alpha1.VolumesStatus{
+		PVCVolumesStatus: []cappv1alpha1.PVCVolumeStatus{{VolumeName: name, ClaimName: name, Phase: phase}},
+	}
+}
+
 func TestBuildCappConditions(t *testing.T) {
-	capp := cappv1alpha1.Capp{}
+	pvcVolumes := cappv1alpha1.VolumesSpec{
+		PVCVolumes: []cappv1alpha1.PVCVolume{{Name: "cache", ClaimName: "cache"}},
+	}
 
 	tests := []struct {
 		name           string
 		status         cappv1alpha1.CappStatus
+		volumes        cappv1alpha1.VolumesSpec
 		enabled        map[string]bool
 		syncErrors     []error
 		expectedStatus metav1.ConditionStatus
@@ -290,6 +299,54 @@ func TestBuildCappConditions(t *testing.T) {
 			expectedReason: cappv1alpha1.CappReadyReasonReady,
 		},
 
+		// --- PVC Volumes ---
+		{
+			name: "not ready when a PVC volume claim is pending",
+			status: cappv1alpha1.CappStatus{
+				KnativeObjectStatus: knativeServiceReady(corev1.ConditionTrue),
+				VolumesStatus:       pvcVolumesStatus("cache", corev1.ClaimPending),
+			},
+			volumes:        pvcVolumes,
+			enabled:        map[string]bool{},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: cappv1alpha1.CappReadyReasonVolumesNotReady,
+			expectedMsg:    "PVC volume cache is not bound",
+		},
+		{
+			name: "ready when a PVC volume claim is bound",
+			status: cappv1alpha1.CappStatus{
+				KnativeObjectStatus: knativeServiceReady(corev1.ConditionTrue),
+				VolumesStatus:       pvcVolumesStatus("cache", corev1.ClaimBound),
+			},
+			volumes:        pvcVolumes,
+			enabled:        map[string]bool{},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: cappv1alpha1.CappReadyReasonReady,
+		},
+
+		// --- Secrets ---
+		{
+			name: "not ready when an external secret has not been synced",
+			status: cappv1alpha1.CappStatus{
+				KnativeObjectStatus: knativeServiceReady(corev1.ConditionTrue),
+				SecretsStatus:       []cappv1alpha1.ExternalSecretStatus{{Name: "db", SecretName: "my-capp-db"}},
+			},
+			enabled:        map[string]bool{},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: cappv1alpha1.CappReadyReasonSecretsNotReady,
+			expectedMsg:    "secret db has not been synced yet",
+		},
+		{
+			name: "ready when all external secrets have been synced",
+			status: cappv1alpha1.CappStatus{
+				KnativeObjectStatus: knativeServiceReady(corev1.ConditionTrue),
+				SecretsStatus:       []cappv1alpha1.ExternalSecretStatus{{Name: "db", SecretName: "my-capp-db", Synced: true}},
+			},
+			enabled:        map[string]bool{},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: cappv1alpha1.CappReadyReasonReady,
+		},
+
 		// --- Eventing ---
 		{
 			name: "not ready when PingSource enabled and event source not ready",
@@ -421,6 +478,7 @@ func TestBuildCappConditions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			status := tt.status
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: tt.volumes}}
 			managers := buildManagers(tt.enabled)
 			buildCappConditions(&status, capp, managers, tt.syncErrors)
 
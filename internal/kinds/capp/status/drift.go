@@ -0,0 +1,39 @@
+package status
+
+import (
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildDriftedCondition reports the child resources whose out-of-band changes were left in place
+// because drift correction is paused for the Capp.
+func buildDriftedCondition(drifts []rmanagers.Drift) metav1.Condition {
+	if len(drifts) == 0 {
+		return metav1.Condition{
+			Type:    cappv1alpha1.CappConditionDrifted,
+			Status:  metav1.ConditionFalse,
+			Reason:  cappv1alpha1.CappDriftReasonNone,
+			Message: "Child resources match the Capp",
+		}
+	}
+
+	messages := make([]string, len(drifts))
+	for i, drift := range drifts {
+		messages[i] = drift.String()
+	}
+
+	message := strings.Join(messages, "; ")
+	if len(message) > maxSyncErrorMessageLen {
+		message = message[:maxSyncErrorMessageLen] + "...(truncated)"
+	}
+
+	return metav1.Condition{
+		Type:    cappv1alpha1.CappConditionDrifted,
+		Status:  metav1.ConditionTrue,
+		Reason:  cappv1alpha1.CappDriftReasonDetected,
+		Message: message,
+	}
+}
@@ -0,0 +1,30 @@
+package status
+
+import (
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildDriftedCondition(t *testing.T) {
+	t.Run("is false without drift", func(t *testing.T) {
+		condition := buildDriftedCondition(nil)
+		assert.Equal(t, cappv1alpha1.CappConditionDrifted, condition.Type)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, cappv1alpha1.CappDriftReasonNone, condition.Reason)
+	})
+
+	t.Run("lists every drifted resource", func(t *testing.T) {
+		condition := buildDriftedCondition([]rmanagers.Drift{
+			{Kind: rmanagers.KnativeService, Name: "test-capp", Fields: []string{`spec.template.spec.containers[0].image: "drifted" -> "v1"`}},
+			{Kind: rmanagers.DomainMapping, Name: "app.example.com", Fields: []string{`spec.ref.name: "other" -> "test-capp"`}},
+		})
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, cappv1alpha1.CappDriftReasonDetected, condition.Reason)
+		assert.Contains(t, condition.Message, "KnativeService test-capp: spec.template.spec.containers[0].image")
+		assert.Contains(t, condition.Message, "DomainMapping app.example.com: spec.ref.name")
+	})
+}
@@ -0,0 +1,16 @@
+package status
+
+import (
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildPausedCondition reports that the child resources of the Capp are not reconciled.
+func buildPausedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    cappv1alpha1.CappConditionPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  cappv1alpha1.CappPausedReasonPaused,
+		Message: "Reconciliation of child resources and CappRevisions is paused",
+	}
+}
@@ -0,0 +1,16 @@
+package status
+
+import (
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildPausedCondition(t *testing.T) {
+	condition := buildPausedCondition()
+	assert.Equal(t, cappv1alpha1.CappConditionPaused, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, cappv1alpha1.CappPausedReasonPaused, condition.Reason)
+}
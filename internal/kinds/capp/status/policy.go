@@ -0,0 +1,40 @@
+package status
+
+import (
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/policy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildPolicyCompliantCondition re-runs the admission checks that depend on the CappConfig against
+// the effective config, so that Capps admitted under looser limits are reported once the config is tightened.
+func buildPolicyCompliantCondition(capp cappv1alpha1.Capp, cappConfig *cappv1alpha1.CappConfig) metav1.Condition {
+	violations := policy.Violations(capp, cappConfig.Spec)
+	if len(violations) == 0 {
+		return metav1.Condition{
+			Type:    cappv1alpha1.CappConditionPolicyCompliant,
+			Status:  metav1.ConditionTrue,
+			Reason:  cappv1alpha1.CappPolicyReasonCompliant,
+			Message: "Capp complies with the CappConfig limits",
+		}
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Error()
+	}
+
+	message := strings.Join(messages, "; ")
+	if len(message) > maxSyncErrorMessageLen {
+		message = message[:maxSyncErrorMessageLen] + "...(truncated)"
+	}
+
+	return metav1.Condition{
+		Type:    cappv1alpha1.CappConditionPolicyCompliant,
+		Status:  metav1.ConditionFalse,
+		Reason:  cappv1alpha1.CappPolicyReasonViolated,
+		Message: message,
+	}
+}
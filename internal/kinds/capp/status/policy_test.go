@@ -0,0 +1,44 @@
+package status
+
+import (
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildPolicyCompliantCondition(t *testing.T) {
+	cappConfig := &cappv1alpha1.CappConfig{
+		Spec: cappv1alpha1.CappConfigSpec{
+			AutoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				MinReplicasLimit: 5,
+				MaxReplicasLimit: 5,
+			},
+			AllowedHostnamePatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.example\.com`}},
+		},
+	}
+
+	t.Run("is true for a compliant Capp", func(t *testing.T) {
+		condition := buildPolicyCompliantCondition(cappv1alpha1.Capp{}, cappConfig)
+		assert.Equal(t, cappv1alpha1.CappConditionPolicyCompliant, condition.Type)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, cappv1alpha1.CappPolicyReasonCompliant, condition.Reason)
+	})
+
+	t.Run("lists every violation after the config is tightened", func(t *testing.T) {
+		capp := cappv1alpha1.Capp{
+			Spec: cappv1alpha1.CappSpec{
+				ScaleSpec: cappv1alpha1.ScaleSpec{MaxReplicas: ptr.To(int32(10))},
+				RouteSpec: cappv1alpha1.RouteSpec{Hostname: "app.other.com"},
+			},
+		}
+
+		condition := buildPolicyCompliantCondition(capp, cappConfig)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, cappv1alpha1.CappPolicyReasonViolated, condition.Reason)
+		assert.Contains(t, condition.Message, "maxReplicas")
+		assert.Contains(t, condition.Message, "app.other.com")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 	knativev1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -43,6 +44,14 @@ func buildRouteStatus(ctx context.Context, kubeClient client.Client, capp cappv1
 	return routeStatus, nil
 }
 
+// internalURL returns the URL the Knative Service is reachable at from inside the cluster, if it has one.
+func internalURL(knativeStatus knativev1.ServiceStatus) string {
+	if knativeStatus.Address == nil || knativeStatus.Address.URL == nil {
+		return ""
+	}
+	return knativeStatus.Address.URL.String()
+}
+
 // buildDomainMappingStatus partly constructs the Route Status of the Capp object in accordance to the
 // status of the corresponding DomainMapping object.
 func buildDomainMappingStatus(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp, isRequired bool, zone string) (knativev1beta1.DomainMappingStatus, error) {
@@ -16,6 +16,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 	knativev1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -127,6 +129,19 @@ func TestBuildRouteStatus(t *testing.T) {
 	})
 }
 
+func TestInternalURL(t *testing.T) {
+	t.Run("returns empty before the service has an address", func(t *testing.T) {
+		assert.Empty(t, internalURL(knativev1.ServiceStatus{}))
+	})
+
+	t.Run("returns the address of the service", func(t *testing.T) {
+		knativeStatus := knativev1.ServiceStatus{}
+		knativeStatus.Address = &duckv1.Addressable{URL: apis.HTTP("my-capp.my-ns.svc.cluster.local")}
+
+		assert.Equal(t, "http://my-capp.my-ns.svc.cluster.local", internalURL(knativeStatus))
+	})
+}
+
 func TestBuildDomainMappingStatus(t *testing.T) {
 	ctx := context.Background()
 	capp := routeCapp()
@@ -0,0 +1,35 @@
+package status
+
+import (
+	"context"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// buildSecretsStatus reports for each external secret of the Capp whether the Secret it is synced into exists.
+func buildSecretsStatus(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp) ([]cappv1alpha1.ExternalSecretStatus, error) {
+	//nolint:prealloc
+	var secretsStatus []cappv1alpha1.ExternalSecretStatus
+
+	for _, secret := range capp.Spec.SecretsSpec.Secrets {
+		secretStatus := cappv1alpha1.ExternalSecretStatus{
+			Name:       secret.Name,
+			SecretName: rmanagers.ExternalSecretName(capp, secret),
+		}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: secretStatus.SecretName}, &corev1.Secret{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			secretStatus.Synced = true
+		}
+		secretsStatus = append(secretsStatus, secretStatus)
+	}
+
+	return secretsStatus, nil
+}
@@ -0,0 +1,29 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBuildSecretsStatus(t *testing.T) {
+	ctx := context.Background()
+
+	capp := newCapp()
+	capp.Spec.SecretsSpec.Secrets = []cappv1alpha1.ExternalSecret{{Name: "db"}, {Name: "api"}}
+	synced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: capp.Name + "-db", Namespace: cappNamespace}}
+	fakeClient := fake.NewClientBuilder().WithScheme(newVolumesScheme()).WithObjects(synced).Build()
+
+	result, err := buildSecretsStatus(ctx, fakeClient, capp)
+	require.NoError(t, err)
+	assert.Equal(t, []cappv1alpha1.ExternalSecretStatus{
+		{Name: "db", SecretName: capp.Name + "-db", Synced: true},
+		{Name: "api", SecretName: capp.Name + "-api"},
+	}, result)
+}
@@ -4,17 +4,26 @@ import (
 	"context"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// buildVolumesStatus constructs the Volumes Status of the Capp object in accordance to the status of the corresponding nfsPVC object if such exists.
+// buildVolumesStatus constructs the Volumes Status of the Capp object in accordance to the status of the corresponding nfsPVC object if such exists,
+// and to the phase of the PersistentVolumeClaims of its PVC volumes.
 func buildVolumesStatus(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp, isRequired bool) (cappv1alpha1.VolumesStatus, error) {
 	volumesStatus := cappv1alpha1.VolumesStatus{}
 
+	pvcVolumesStatus, err := buildPVCVolumesStatus(ctx, kubeClient, capp)
+	if err != nil {
+		return volumesStatus, err
+	}
+	volumesStatus.PVCVolumesStatus = pvcVolumesStatus
+
 	if !isRequired {
 		return volumesStatus, nil
 	}
@@ -36,8 +45,63 @@ func buildVolumesStatus(ctx context.Context, kubeClient client.Client, capp capp
 			VolumeName:   NFSPVC.Name,
 			NFSPVCStatus: NFSPVCObj.Status,
 		}
+		resizing, err := isNFSVolumeResizing(ctx, kubeClient, capp, NFSPVC)
+		if err != nil {
+			return volumesStatus, err
+		}
+		if resizing {
+			NFSPVCStatus.Phase = cappv1alpha1.NFSVolumePhaseResizing
+		}
 		volumesStatus.NFSVolumesStatus = append(volumesStatus.NFSVolumesStatus, NFSPVCStatus)
 	}
 
 	return volumesStatus, nil
 }
+
+// NFSVolumesResizing reports whether the PersistentVolume of any NFS volume of the Capp is still smaller
+// than the capacity of the volume. Nothing notifies the Capp when a PersistentVolume is resized, so it
+// has to be checked again until it reports false.
+func NFSVolumesResizing(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp) (bool, error) {
+	for _, volume := range capp.Spec.VolumesSpec.NFSVolumes {
+		resizing, err := isNFSVolumeResizing(ctx, kubeClient, capp, volume)
+		if err != nil || resizing {
+			return resizing, err
+		}
+	}
+	return false, nil
+}
+
+// isNFSVolumeResizing reports whether the PersistentVolume of the NfsPvc of volume is smaller than the
+// capacity of volume.
+func isNFSVolumeResizing(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp, volume cappv1alpha1.NFSVolume) (bool, error) {
+	pv := corev1.PersistentVolume{}
+	if err := kubeClient.Get(ctx, types.NamespacedName{Name: rmanagers.NFSPersistentVolumeName(volume.Name, capp.Namespace)}, &pv); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	requested, current := volume.Capacity[corev1.ResourceStorage], pv.Spec.Capacity[corev1.ResourceStorage]
+	return current.Cmp(requested) < 0, nil
+}
+
+// buildPVCVolumesStatus returns the phase of the PersistentVolumeClaim of each PVC volume of the Capp.
+func buildPVCVolumesStatus(ctx context.Context, kubeClient client.Client, capp cappv1alpha1.Capp) ([]cappv1alpha1.PVCVolumeStatus, error) {
+	//nolint:prealloc
+	var pvcVolumesStatus []cappv1alpha1.PVCVolumeStatus
+
+	for _, volume := range capp.Spec.VolumesSpec.PVCVolumes {
+		volumeStatus := cappv1alpha1.PVCVolumeStatus{
+			VolumeName: volume.Name,
+			ClaimName:  rmanagers.PVCClaimName(capp, volume),
+		}
+		pvc := corev1.PersistentVolumeClaim{}
+		if err := kubeClient.Get(ctx, types.NamespacedName{Namespace: capp.Namespace, Name: volumeStatus.ClaimName}, &pvc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			volumeStatus.Phase = pvc.Status.Phase
+		}
+		pvcVolumesStatus = append(pvcVolumesStatus, volumeStatus)
+	}
+
+	return pvcVolumesStatus, nil
+}
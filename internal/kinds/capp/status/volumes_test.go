@@ -8,9 +8,12 @@ import (
 	nfspvcv1alpha1 "github.com/dana-team/nfspvc-operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -21,6 +24,7 @@ const (
 
 func newVolumesScheme() *runtime.Scheme {
 	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
 	utilruntime.Must(cappv1alpha1.AddToScheme(s))
 	utilruntime.Must(nfspvcv1alpha1.AddToScheme(s))
 	return s
@@ -81,6 +85,38 @@ func TestBuildVolumesStatus(t *testing.T) {
 		assert.Equal(t, phaseBound, result.NFSVolumesStatus[0].NFSPVCStatus.PvcPhase)
 	})
 
+	t.Run("reports resizing until the persistent volume reflects the capacity", func(t *testing.T) {
+		capp := newCapp()
+		capp.Spec.VolumesSpec.NFSVolumes = []cappv1alpha1.NFSVolume{
+			{Name: volumeName, Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")}},
+		}
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: volumeName + "-" + cappNamespace + "-pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(newVolumesScheme()).
+			WithObjects(newNfsPvc(volumeName, nfspvcv1alpha1.NfsPvcStatus{PvPhase: phaseBound}), pv).Build()
+
+		result, err := buildVolumesStatus(ctx, fakeClient, capp, true)
+		require.NoError(t, err)
+		assert.Equal(t, cappv1alpha1.NFSVolumePhaseResizing, result.NFSVolumesStatus[0].Phase)
+		resizing, err := NFSVolumesResizing(ctx, fakeClient, capp)
+		require.NoError(t, err)
+		assert.True(t, resizing)
+
+		pv.Spec.Capacity[corev1.ResourceStorage] = resource.MustParse("5Gi")
+		require.NoError(t, fakeClient.Update(ctx, pv))
+
+		result, err = buildVolumesStatus(ctx, fakeClient, capp, true)
+		require.NoError(t, err)
+		assert.Empty(t, result.NFSVolumesStatus[0].Phase)
+		resizing, err = NFSVolumesResizing(ctx, fakeClient, capp)
+		require.NoError(t, err)
+		assert.False(t, resizing)
+	})
+
 	t.Run("handles multiple volumes with mixed existence", func(t *testing.T) {
 		capp := newCapp()
 		capp.Spec.VolumesSpec.NFSVolumes = []cappv1alpha1.NFSVolume{
@@ -102,3 +138,26 @@ func TestBuildVolumesStatus(t *testing.T) {
 		assert.Equal(t, nfspvcv1alpha1.NfsPvcStatus{}, result.NFSVolumesStatus[1].NFSPVCStatus)
 	})
 }
+
+func TestBuildPVCVolumesStatus(t *testing.T) {
+	ctx := context.Background()
+
+	capp := newCapp()
+	capp.Spec.VolumesSpec.PVCVolumes = []cappv1alpha1.PVCVolume{
+		{Name: volumeName, ClaimName: "existing-claim"},
+		{Name: "vol-b", Size: ptr.To(resource.MustParse("1Gi"))},
+	}
+	existing := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing-claim", Namespace: cappNamespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(newVolumesScheme()).WithObjects(existing).Build()
+
+	result, err := buildVolumesStatus(ctx, fakeClient, capp, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.NFSVolumesStatus)
+	assert.Equal(t, []cappv1alpha1.PVCVolumeStatus{
+		{VolumeName: volumeName, ClaimName: "existing-claim", Phase: corev1.ClaimBound},
+		{VolumeName: "vol-b", ClaimName: capp.Name + "-vol-b"},
+	}, result.PVCVolumesStatus)
+}
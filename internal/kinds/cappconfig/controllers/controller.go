@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/policy"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	dnsv1beta1 "github.com/dana-team/provider-dns-v2/apis/namespaced/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	cappConfigControllerName = "CappConfigController"
+	eventCappConfigInvalid   = "CappConfigInvalid"
+	eventActionValidate      = "Validate"
+	RequeueTime              = 5 * time.Second
+	// ViolationsRecountInterval is how often the Capps violating their effective config are recounted.
+	// Capp changes do not trigger a recount, since counting lists every Capp in the cluster.
+	ViolationsRecountInterval = 5 * time.Minute
+)
+
+// CappConfigReconciler reconciles the global CappConfig object
+type CappConfigReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	EventRecorder events.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=rcs.dana.io,resources=cappconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rcs.dana.io,resources=cappconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rcs.dana.io,resources=cappconfigoverrides,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rcs.dana.io,resources=capps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="cert-manager.io",resources=clusterissuers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="dns-v2.m.crossplane.io",resources=clusterproviderconfigs,verbs=get;list;watch
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CappConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&cappv1alpha1.CappConfig{},
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+				predicate.NewPredicateFuncs(isGlobalCappConfig),
+			),
+		).
+		Named(cappConfigControllerName).
+		Watches(
+			&cappv1alpha1.CappConfigOverride{},
+			handler.EnqueueRequestsFromMapFunc(enqueueGlobalCappConfig),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// isGlobalCappConfig reports whether the object is the CappConfig the operator actually uses.
+func isGlobalCappConfig(object client.Object) bool {
+	return object.GetName() == cappmeta.CappConfigName && object.GetNamespace() == cappmeta.CappNS
+}
+
+// enqueueGlobalCappConfig maps any watched object to the global CappConfig, so that the
+// number of violating Capps is recounted whenever an override changes.
+func enqueueGlobalCappConfig(_ context.Context, _ client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: cappmeta.CappNS,
+		Name:      cappmeta.CappConfigName,
+	}}}
+}
+
+func (r *CappConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("CappConfigName", req.Name, "CappConfigNamespace", req.Namespace)
+	logger.Info("Starting Reconcile")
+
+	cappConfig := cappv1alpha1.CappConfig{}
+	if err := r.Get(ctx, req.NamespacedName, &cappConfig); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("CappConfig does not exist")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get CappConfig: %w", err)
+	}
+
+	problems, err := validateCappConfig(ctx, r.Client, cappConfig.Spec)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to validate CappConfig: %w", err)
+	}
+
+	violatingCapps, err := countViolatingCapps(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to count violating Capps: %w", err)
+	}
+
+	oldStatus := cappConfig.Status.DeepCopy()
+	cappConfig.Status.ObservedGeneration = cappConfig.Generation
+	cappConfig.Status.ViolatingCapps = violatingCapps
+	meta.SetStatusCondition(&cappConfig.Status.Conditions, validCondition(problems, cappConfig.Generation))
+	meta.SetStatusCondition(&cappConfig.Status.Conditions, cappsCompliantCondition(violatingCapps, cappConfig.Generation))
+
+	// Only report the problems once per change, since the periodic recount keeps re-triggering this reconcile.
+	oldValid := meta.FindStatusCondition(oldStatus.Conditions, cappv1alpha1.CappConfigConditionValid)
+	newValid := meta.FindStatusCondition(cappConfig.Status.Conditions, cappv1alpha1.CappConfigConditionValid)
+	if len(problems) > 0 && (oldValid == nil || oldValid.Message != newValid.Message) {
+		r.EventRecorder.Eventf(&cappConfig, nil, corev1.EventTypeWarning, eventCappConfigInvalid, eventActionValidate,
+			"CappConfig is invalid: %s", newValid.Message)
+	}
+
+	if equality.Semantic.DeepEqual(*oldStatus, cappConfig.Status) {
+		return ctrl.Result{RequeueAfter: ViolationsRecountInterval}, nil
+	}
+
+	logger.Info("kubernetes API write status update", cappmeta.ObjectIdentityKeyVals(&cappConfig)...)
+	if err := r.Status().Update(ctx, &cappConfig); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{RequeueAfter: RequeueTime}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to update CappConfig status: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: ViolationsRecountInterval}, nil
+}
+
+// validateCappConfig returns a human-readable list of the problems found in the CappConfig spec.
+// An error is returned only when the validation itself could not be completed.
+func validateCappConfig(ctx context.Context, k8sClient client.Client, spec cappv1alpha1.CappConfigSpec) ([]string, error) {
+	var problems []string
+
+	for i, hp := range spec.AllowedHostnamePatterns {
+		if _, err := regexp.Compile(hp.Match); err != nil {
+			problems = append(problems, fmt.Sprintf("allowedHostnamePatterns[%d]: invalid pattern %q: %s", i, hp.Match, err))
+		}
+	}
+
+	autoscaleConfig := spec.AutoscaleConfig
+	if autoscaleConfig.MinReplicasLimit > autoscaleConfig.MaxReplicasLimit {
+		problems = append(problems, fmt.Sprintf("autoscaleConfig: minReplicasLimit %d must be less than or equal to maxReplicasLimit %d",
+			autoscaleConfig.MinReplicasLimit, autoscaleConfig.MaxReplicasLimit))
+	}
+	if autoscaleConfig.ActivationScale > autoscaleConfig.MaxReplicasLimit {
+		problems = append(problems, fmt.Sprintf("autoscaleConfig: activationScale %d must be less than or equal to maxReplicasLimit %d",
+			autoscaleConfig.ActivationScale, autoscaleConfig.MaxReplicasLimit))
+	}
+
+	issuerProblem, err := checkIssuerExists(ctx, k8sClient, spec.DNSConfig.IssuerRef)
+	if err != nil {
+		return nil, err
+	}
+	if issuerProblem != "" {
+		problems = append(problems, issuerProblem)
+	}
+
+	providerProblem, err := checkProviderConfigExists(ctx, k8sClient, spec.DNSConfig.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if providerProblem != "" {
+		problems = append(problems, providerProblem)
+	}
+
+	return problems, nil
+}
+
+// checkIssuerExists makes sure the referenced cert-manager ClusterIssuer exists. Namespaced
+// Issuers are resolved in each Capp namespace, so they cannot be checked from the config alone.
+func checkIssuerExists(ctx context.Context, k8sClient client.Client, issuerRef cappv1alpha1.IssuerRef) (string, error) {
+	if issuerRef.Group != cmapi.SchemeGroupVersion.Group || issuerRef.Kind != cmapi.ClusterIssuerKind {
+		return "", nil
+	}
+
+	issuer := cmapi.ClusterIssuer{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: issuerRef.Name}, &issuer); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return fmt.Sprintf("dnsConfig.issuerRef: ClusterIssuer %q not found", issuerRef.Name), nil
+		}
+		return "", fmt.Errorf("failed to get ClusterIssuer %q: %w", issuerRef.Name, err)
+	}
+	return "", nil
+}
+
+// checkProviderConfigExists makes sure the ClusterProviderConfig referenced by the DNS config exists.
+func checkProviderConfigExists(ctx context.Context, k8sClient client.Client, provider string) (string, error) {
+	if provider == "" {
+		return "", nil
+	}
+
+	providerConfig := dnsv1beta1.ClusterProviderConfig{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: provider}, &providerConfig); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return fmt.Sprintf("dnsConfig.provider: %s %q not found", rmanagers.ClusterProviderConfigKind, provider), nil
+		}
+		return "", fmt.Errorf("failed to get %s %q: %w", rmanagers.ClusterProviderConfigKind, provider, err)
+	}
+	return "", nil
+}
+
+// countViolatingCapps returns the number of Capps that violate the limits of their effective
+// config. The effective config is resolved once per namespace.
+func countViolatingCapps(ctx context.Context, k8sClient client.Client) (int32, error) {
+	cappList := cappv1alpha1.CappList{}
+	if err := k8sClient.List(ctx, &cappList); err != nil {
+		return 0, err
+	}
+
+	effectiveConfigs := make(map[string]*cappv1alpha1.CappConfig)
+	var violating int32
+	for _, capp := range cappList.Items {
+		effectiveConfig, ok := effectiveConfigs[capp.Namespace]
+		if !ok {
+			var err error
+			effectiveConfig, _, err = rmanagers.GetEffectiveCappConfig(ctx, k8sClient, capp.Namespace)
+			if err != nil {
+				return 0, err
+			}
+			effectiveConfigs[capp.Namespace] = effectiveConfig
+		}
+
+		if len(policy.Violations(capp, effectiveConfig.Spec)) > 0 {
+			violating++
+		}
+	}
+
+	return violating, nil
+}
+
+func validCondition(problems []string, generation int64) metav1.Condition {
+	if len(problems) > 0 {
+		return metav1.Condition{
+			Type:               cappv1alpha1.CappConfigConditionValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             cappv1alpha1.CappConfigReasonInvalid,
+			Message:            strings.Join(problems, "; "),
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               cappv1alpha1.CappConfigConditionValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             cappv1alpha1.CappConfigReasonValid,
+		Message:            "CappConfig is valid",
+		ObservedGeneration: generation,
+	}
+}
+
+func cappsCompliantCondition(violatingCapps int32, generation int64) metav1.Condition {
+	if violatingCapps > 0 {
+		return metav1.Condition{
+			Type:               cappv1alpha1.CappConfigConditionCappsCompliant,
+			Status:             metav1.ConditionFalse,
+			Reason:             cappv1alpha1.CappConfigReasonCappsViolateLimits,
+			Message:            fmt.Sprintf("%d Capps violate the limits of their effective config", violatingCapps),
+			ObservedGeneration: generation,
+		}
+	}
+	return metav1.Condition{
+		Type:               cappv1alpha1.CappConfigConditionCappsCompliant,
+		Status:             metav1.ConditionTrue,
+		Reason:             cappv1alpha1.CappConfigReasonAllCappsCompliant,
+		Message:            "All Capps comply with the limits of their effective config",
+		ObservedGeneration: generation,
+	}
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	dnsv1beta1 "github.com/dana-team/provider-dns-v2/apis/namespaced/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	issuerName   = "cert-issuer"
+	providerName = "dns-default"
+	cappNs       = "test-ns"
+)
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(s))
+	utilruntime.Must(cappv1alpha1.AddToScheme(s))
+	utilruntime.Must(cmapi.AddToScheme(s))
+	utilruntime.Must(dnsv1beta1.SchemeBuilder.AddToScheme(s))
+	return s
+}
+
+func newCappConfig() *cappv1alpha1.CappConfig {
+	return &cappv1alpha1.CappConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       cappmeta.CappConfigName,
+			Namespace:  cappmeta.CappNS,
+			Generation: 2,
+		},
+		Spec: cappv1alpha1.CappConfigSpec{
+			DNSConfig: cappv1alpha1.DNSConfig{
+				Provider: providerName,
+				IssuerRef: cappv1alpha1.IssuerRef{
+					Name:  issuerName,
+					Kind:  cmapi.ClusterIssuerKind,
+					Group: cmapi.SchemeGroupVersion.Group,
+				},
+			},
+			AutoscaleConfig: cappv1alpha1.AutoscaleConfig{
+				ActivationScale:  2,
+				MinReplicasLimit: 5,
+				MaxReplicasLimit: 10,
+				MaxScaleDelay:    100,
+			},
+			AllowedHostnamePatterns: []cappv1alpha1.HostnamePattern{{Match: ".*"}},
+			MaxKafkaConsumers:       5,
+		},
+	}
+}
+
+func newCapp(name string, minReplicas int32) *cappv1alpha1.Capp {
+	return &cappv1alpha1.Capp{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cappNs},
+		Spec: cappv1alpha1.CappSpec{
+			ScaleSpec: cappv1alpha1.ScaleSpec{MinReplicas: ptr.To(minReplicas)},
+		},
+	}
+}
+
+func reconcileCappConfig(t *testing.T, objects ...client.Object) *cappv1alpha1.CappConfig {
+	t.Helper()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme()).
+		WithObjects(objects...).
+		WithStatusSubresource(&cappv1alpha1.CappConfig{}).
+		Build()
+	r := &CappConfigReconciler{Client: k8sClient, EventRecorder: events.NewFakeRecorder(10)}
+
+	key := types.NamespacedName{Name: cappmeta.CappConfigName, Namespace: cappmeta.CappNS}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.Equal(t, ViolationsRecountInterval, result.RequeueAfter, "the violating Capps should be recounted periodically")
+
+	cappConfig := &cappv1alpha1.CappConfig{}
+	require.NoError(t, k8sClient.Get(context.Background(), key, cappConfig))
+	return cappConfig
+}
+
+func TestReconcile(t *testing.T) {
+	issuer := &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: issuerName}}
+	provider := &dnsv1beta1.ClusterProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: providerName}}
+
+	t.Run("marks a valid config with compliant Capps", func(t *testing.T) {
+		cappConfig := reconcileCappConfig(t, newCappConfig(), issuer, provider, newCapp("a", 5))
+
+		assert.Equal(t, int64(2), cappConfig.Status.ObservedGeneration)
+		assert.Zero(t, cappConfig.Status.ViolatingCapps)
+		assert.True(t, meta.IsStatusConditionTrue(cappConfig.Status.Conditions, cappv1alpha1.CappConfigConditionValid))
+		assert.True(t, meta.IsStatusConditionTrue(cappConfig.Status.Conditions, cappv1alpha1.CappConfigConditionCappsCompliant))
+	})
+
+	t.Run("reports every validation problem", func(t *testing.T) {
+		cfg := newCappConfig()
+		cfg.Spec.AllowedHostnamePatterns = []cappv1alpha1.HostnamePattern{{Match: "("}}
+		cfg.Spec.AutoscaleConfig.MinReplicasLimit = 20
+
+		cappConfig := reconcileCappConfig(t, cfg)
+
+		valid := meta.FindStatusCondition(cappConfig.Status.Conditions, cappv1alpha1.CappConfigConditionValid)
+		require.NotNil(t, valid)
+		assert.Equal(t, metav1.ConditionFalse, valid.Status)
+		assert.Equal(t, cappv1alpha1.CappConfigReasonInvalid, valid.Reason)
+		for _, s := range []string{"allowedHostnamePatterns[0]", "minReplicasLimit", "ClusterIssuer", providerName} {
+			assert.Contains(t, valid.Message, s)
+		}
+	})
+
+	t.Run("counts Capps violating the limits", func(t *testing.T) {
+		cappConfig := reconcileCappConfig(t, newCappConfig(), issuer, provider,
+			newCapp("a", 5), newCapp("b", 6), newCapp("c", 7))
+
+		assert.Equal(t, int32(2), cappConfig.Status.ViolatingCapps)
+		compliant := meta.FindStatusCondition(cappConfig.Status.Conditions, cappv1alpha1.CappConfigConditionCappsCompliant)
+		require.NotNil(t, compliant)
+		assert.Equal(t, cappv1alpha1.CappConfigReasonCappsViolateLimits, compliant.Reason)
+	})
+
+	t.Run("uses the effective config of each namespace", func(t *testing.T) {
+		override := &cappv1alpha1.CappConfigOverride{
+			ObjectMeta: metav1.ObjectMeta{Name: "raise-limits", Namespace: cappmeta.CappNS},
+			Spec: cappv1alpha1.CappConfigOverrideSpec{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: cappNs}},
+				AutoscaleConfig:   &cappv1alpha1.AutoscaleConfigOverride{MinReplicasLimit: ptr.To(10)},
+			},
+		}
+		cappConfig := reconcileCappConfig(t, newCappConfig(), issuer, provider, override, newCapp("b", 6))
+
+		assert.Zero(t, cappConfig.Status.ViolatingCapps)
+	})
+}
@@ -0,0 +1,148 @@
+package actionmanagers
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// buildRevisionStatus returns the status of a new revision of capp, recording the fields changed
+// relative to previous, if there is a previous revision, and the user who last updated capp.
+func buildRevisionStatus(capp cappv1alpha1.Capp, previous *cappv1alpha1.CappRevision) (cappv1alpha1.CappRevisionStatus, error) {
+	status := cappv1alpha1.CappRevisionStatus{UpdatedBy: capp.Annotations[cappmeta.LastUpdatedByAnnotationKey]}
+	if previous == nil {
+		return status, nil
+	}
+
+	current := cappv1alpha1.CappTemplate{Spec: capp.Spec, Labels: capp.Labels, Annotations: capp.Annotations}
+	changedFields, err := changedTemplateFields(previous.Spec.CappTemplate, current)
+	if err != nil {
+		return status, err
+	}
+	status.PreviousRevisionNumber = previous.Spec.RevisionNumber
+	status.ChangedFields = changedFields
+	return status, nil
+}
+
+// changedTemplateFields returns the paths, relative to the Capp, of the fields that differ between two
+// Capp templates, ignoring the last-updated-by annotation.
+func changedTemplateFields(from, to cappv1alpha1.CappTemplate) ([]string, error) {
+	fromFields, err := templateFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := templateFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	diffPaths("", fromFields, toFields, &changed)
+	return changed, nil
+}
+
+func templateFields(template cappv1alpha1.CappTemplate) (map[string]any, error) {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&template.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Capp spec: %w", err)
+	}
+
+	annotations := make(map[string]any, len(template.Annotations))
+	for key, value := range template.Annotations {
+		if key != annotationToIgnore {
+			annotations[key] = value
+		}
+	}
+	labels := make(map[string]any, len(template.Labels))
+	for key, value := range template.Labels {
+		labels[key] = value
+	}
+
+	return map[string]any{
+		"metadata": map[string]any{"labels": labels, "annotations": annotations},
+		"spec":     spec,
+	}, nil
+}
+
+// diffPaths appends to changed the paths under path whose values differ between from and to. A field
+// present on one side only is reported once, without descending into it.
+func diffPaths(path string, from, to any, changed *[]string) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]any)
+	toMap, toIsMap := to.(map[string]any)
+	if fromIsMap && toIsMap {
+		for _, key := range sortedKeys(fromMap, toMap) {
+			diffPaths(joinPath(path, key), fromMap[key], toMap[key], changed)
+		}
+		return
+	}
+
+	fromNamed, fromIsNamed := namedItems(from)
+	toNamed, toIsNamed := namedItems(to)
+	if fromIsNamed && toIsNamed {
+		for _, name := range sortedKeys(fromNamed, toNamed) {
+			diffPaths(fmt.Sprintf("%s[%s]", path, name), fromNamed[name], toNamed[name], changed)
+		}
+		return
+	}
+
+	*changed = append(*changed, path)
+}
+
+// namedItems indexes a list whose items are all objects with a distinct name by that name.
+func namedItems(value any) (map[string]any, bool) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+
+	items := make(map[string]any, len(list))
+	for _, item := range list {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if _, duplicate := items[name]; duplicate {
+			return nil, false
+		}
+		items[name] = object
+	}
+	return items, true
+}
+
+func sortedKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// joinPath appends key to path, in brackets when key is not a plain field name, as for label keys.
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,111 @@
+package actionmanagers
+
+import (
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testUsername  = "alice"
+	containerName = "app"
+)
+
+func newCapp() cappv1alpha1.Capp {
+	capp := cappv1alpha1.Capp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-capp",
+			Namespace:   "my-ns",
+			Labels:      map[string]string{"team": "payments"},
+			Annotations: map[string]string{cappmeta.LastUpdatedByAnnotationKey: testUsername},
+		},
+		Spec: cappv1alpha1.CappSpec{State: cappv1alpha1.CappStateEnabled},
+	}
+	capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{
+		Name:  containerName,
+		Image: "app:v1",
+		Env:   []corev1.EnvVar{{Name: "MODE", Value: "fast"}},
+	}}
+	return capp
+}
+
+func revisionOf(capp cappv1alpha1.Capp, number int) *cappv1alpha1.CappRevision {
+	return &cappv1alpha1.CappRevision{Spec: cappv1alpha1.CappRevisionSpec{
+		RevisionNumber: number,
+		CappTemplate: cappv1alpha1.CappTemplate{
+			Spec:        *capp.Spec.DeepCopy(),
+			Labels:      capp.Labels,
+			Annotations: capp.Annotations,
+		},
+	}}
+}
+
+func TestBuildRevisionStatus(t *testing.T) {
+	t.Run("records only the user on the first revision", func(t *testing.T) {
+		status, err := buildRevisionStatus(newCapp(), nil)
+		require.NoError(t, err)
+		require.Equal(t, cappv1alpha1.CappRevisionStatus{UpdatedBy: testUsername}, status)
+	})
+
+	t.Run("records the changed fields relative to the previous revision", func(t *testing.T) {
+		previous := newCapp()
+		capp := newCapp()
+		container := &capp.Spec.ConfigurationSpec.Template.Spec.Containers[0]
+		container.Image = "app:v2"
+		container.Env = append(container.Env, corev1.EnvVar{Name: "DEBUG", Value: "1"})
+		capp.Spec.ScaleSpec.MinReplicas = ptr.To(int32(2))
+		capp.Spec.RouteSpec.Hostname = "my-app.example.com"
+		capp.Annotations = map[string]string{cappmeta.LastUpdatedByAnnotationKey: "bob"}
+
+		status, err := buildRevisionStatus(capp, revisionOf(previous, 4))
+		require.NoError(t, err)
+		require.Equal(t, 4, status.PreviousRevisionNumber)
+		require.Equal(t, "bob", status.UpdatedBy)
+		require.Equal(t, []string{
+			"spec.configurationSpec.template.spec.containers[app].env[DEBUG]",
+			"spec.configurationSpec.template.spec.containers[app].image",
+			"spec.routeSpec.hostname",
+			"spec.scaleSpec.minReplicas",
+		}, status.ChangedFields)
+	})
+
+	t.Run("addresses label and annotation keys in brackets", func(t *testing.T) {
+		previous := newCapp()
+		capp := newCapp()
+		capp.Labels = map[string]string{"team": "checkout", "app.kubernetes.io/name": "shop"}
+		capp.Annotations[cappmeta.DriftCorrectionAnnotationKey] = cappmeta.DriftCorrectionPaused
+
+		status, err := buildRevisionStatus(capp, revisionOf(previous, 1))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"metadata.annotations[rcs.dana.io/drift-correction]",
+			"metadata.labels[app.kubernetes.io/name]",
+			"metadata.labels.team",
+		}, status.ChangedFields)
+	})
+
+	t.Run("reports a changed list without names as a whole", func(t *testing.T) {
+		previous := newCapp()
+		capp := newCapp()
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Args = []string{"--verbose"}
+
+		status, err := buildRevisionStatus(capp, revisionOf(previous, 1))
+		require.NoError(t, err)
+		require.Equal(t, []string{"spec.configurationSpec.template.spec.containers[app].args"}, status.ChangedFields)
+	})
+
+	t.Run("ignores a change of the last-updated-by annotation alone", func(t *testing.T) {
+		previous := newCapp()
+		capp := newCapp()
+		capp.Annotations = map[string]string{cappmeta.LastUpdatedByAnnotationKey: "bob"}
+
+		status, err := buildRevisionStatus(capp, revisionOf(previous, 1))
+		require.NoError(t, err)
+		require.Empty(t, status.ChangedFields)
+	})
+}
@@ -11,5 +11,9 @@ import (
 
 // HandleCappCreation creates the initial CappRevision.
 func HandleCappCreation(ctx context.Context, k8sClient client.Client, capp cappv1alpha1.Capp, logger logr.Logger) error {
-	return adapters.CreateCappRevision(ctx, k8sClient, logger, capp, 1)
+	status, err := buildRevisionStatus(capp, nil)
+	if err != nil {
+		return err
+	}
+	return adapters.CreateCappRevision(ctx, k8sClient, logger, capp, 1, status)
 }
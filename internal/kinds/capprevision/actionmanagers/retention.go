@@ -0,0 +1,80 @@
+package actionmanagers
+
+import (
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+)
+
+// revisionRetention holds the rules deciding which CappRevisions of a Capp are pruned.
+type revisionRetention struct {
+	// limit is the number of unprotected revisions retained, including the latest one.
+	limit int
+	// period is how long unprotected revisions are retained; zero retains them regardless of age.
+	period time.Duration
+	// pinnedRevisionName is the Knative revision the Capp route pins traffic to, if any.
+	pinnedRevisionName string
+}
+
+// newRevisionRetention returns the retention rules for capp under cappConfig. The revision history
+// limit set on the Capp takes precedence over the one of the CappConfig.
+func newRevisionRetention(capp cappv1alpha1.Capp, cappConfig *cappv1alpha1.CappConfig) revisionRetention {
+	retention := revisionRetention{
+		limit:              max(cappConfig.Spec.RevisionHistoryLimit, 1),
+		pinnedRevisionName: capp.Spec.RouteSpec.TrafficTarget.RevisionName,
+	}
+	if capp.Spec.RevisionHistoryLimit != nil {
+		retention.limit = max(*capp.Spec.RevisionHistoryLimit, 1)
+	}
+	if cappConfig.Spec.RevisionRetentionPeriod != nil {
+		retention.period = cappConfig.Spec.RevisionRetentionPeriod.Duration
+	}
+	return retention
+}
+
+// isProtected reports whether revision is never pruned: it is labeled as protected, or it created
+// the Knative revision the Capp route pins traffic to.
+func (r revisionRetention) isProtected(revision cappv1alpha1.CappRevision) bool {
+	if revision.Labels[cappmeta.ProtectedRevisionLabelKey] == "true" {
+		return true
+	}
+	return r.pinnedRevisionName != "" &&
+		revision.Spec.CappTemplate.Spec.ConfigurationSpec.Template.Name == r.pinnedRevisionName
+}
+
+// revisionsToPrune returns the revisions, ordered newest first, that exceed the retention limit or
+// period, along with the time until the next retained revision expires, or zero if none will.
+// The newest revision and protected revisions are always retained, and protected revisions do not
+// count towards the limit.
+func (r revisionRetention) revisionsToPrune(revisions []cappv1alpha1.CappRevision, now time.Time) ([]cappv1alpha1.CappRevision, time.Duration) {
+	var (
+		toPrune   []cappv1alpha1.CappRevision
+		nextCheck time.Duration
+		retained  int
+	)
+	for i, revision := range revisions {
+		if r.isProtected(revision) {
+			continue
+		}
+		retained++
+		if i == 0 {
+			continue
+		}
+		if retained > r.limit {
+			toPrune = append(toPrune, revision)
+			continue
+		}
+		if r.period > 0 {
+			untilExpiry := revision.CreationTimestamp.Add(r.period).Sub(now)
+			if untilExpiry <= 0 {
+				toPrune = append(toPrune, revision)
+				continue
+			}
+			if nextCheck == 0 || untilExpiry < nextCheck {
+				nextCheck = untilExpiry
+			}
+		}
+	}
+	return toPrune, nextCheck
+}
@@ -0,0 +1,96 @@
+package actionmanagers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newRevisions returns revisions numbered count down to 1, newest first, created a day apart.
+func newRevisions(count int) []cappv1alpha1.CappRevision {
+	revisions := make([]cappv1alpha1.CappRevision, 0, count)
+	for number := count; number >= 1; number-- {
+		revisions = append(revisions, cappv1alpha1.CappRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("my-capp-%05d", number),
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Duration(count-number) * 24 * time.Hour)),
+			},
+			Spec: cappv1alpha1.CappRevisionSpec{RevisionNumber: number},
+		})
+	}
+	return revisions
+}
+
+func revisionNumbers(revisions []cappv1alpha1.CappRevision) []int {
+	numbers := make([]int, 0, len(revisions))
+	for _, revision := range revisions {
+		numbers = append(numbers, revision.Spec.RevisionNumber)
+	}
+	return numbers
+}
+
+func TestNewRevisionRetention(t *testing.T) {
+	cappConfig := &cappv1alpha1.CappConfig{Spec: cappv1alpha1.CappConfigSpec{
+		RevisionHistoryLimit:    10,
+		RevisionRetentionPeriod: &metav1.Duration{Duration: time.Hour},
+	}}
+
+	t.Run("uses the limit and period of the CappConfig", func(t *testing.T) {
+		retention := newRevisionRetention(newCapp(), cappConfig)
+		require.Equal(t, 10, retention.limit)
+		require.Equal(t, time.Hour, retention.period)
+	})
+
+	t.Run("prefers the limit set on the Capp", func(t *testing.T) {
+		capp := newCapp()
+		capp.Spec.RevisionHistoryLimit = ptr.To(3)
+		require.Equal(t, 3, newRevisionRetention(capp, cappConfig).limit)
+	})
+}
+
+func TestRevisionsToPrune(t *testing.T) {
+	t.Run("prunes revisions beyond the limit", func(t *testing.T) {
+		toPrune, nextCheck := revisionRetention{limit: 3}.revisionsToPrune(newRevisions(5), now)
+		require.Equal(t, []int{2, 1}, revisionNumbers(toPrune))
+		require.Zero(t, nextCheck)
+	})
+
+	t.Run("prunes revisions older than the period and reports the next expiry", func(t *testing.T) {
+		retention := revisionRetention{limit: 10, period: 36 * time.Hour}
+		toPrune, nextCheck := retention.revisionsToPrune(newRevisions(4), now)
+		require.Equal(t, []int{2, 1}, revisionNumbers(toPrune))
+		require.Equal(t, 12*time.Hour, nextCheck)
+	})
+
+	t.Run("keeps the newest revision regardless of age", func(t *testing.T) {
+		revisions := newRevisions(2)
+		retention := revisionRetention{limit: 10, period: time.Hour}
+		toPrune, _ := retention.revisionsToPrune(revisions, now.Add(48*time.Hour))
+		require.Equal(t, []int{1}, revisionNumbers(toPrune))
+	})
+
+	t.Run("keeps labeled revisions without counting them", func(t *testing.T) {
+		revisions := newRevisions(5)
+		revisions[3].Labels = map[string]string{cappmeta.ProtectedRevisionLabelKey: "true"}
+
+		toPrune, _ := revisionRetention{limit: 2}.revisionsToPrune(revisions, now)
+		require.Equal(t, []int{3, 1}, revisionNumbers(toPrune))
+	})
+
+	t.Run("keeps the revision pinned by the route traffic target", func(t *testing.T) {
+		revisions := newRevisions(4)
+		revisions[3].Spec.CappTemplate.Spec.ConfigurationSpec.Template.Name = "my-capp-v1"
+
+		retention := revisionRetention{limit: 1, period: time.Hour, pinnedRevisionName: "my-capp-v1"}
+		toPrune, _ := retention.revisionsToPrune(revisions, now)
+		require.Equal(t, []int{3, 2}, revisionNumbers(toPrune))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"maps"
 	"sort"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 
@@ -15,21 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var annotationToIgnore = cappmeta.CappAPIGroup + "/last-updated-by"
-
-// splitRevisionsAtIndex splits a slice of CappRevisions into two slices:
-// one containing the elements before the specified index (exclusive),
-// and one containing the elements after the specified index (inclusive).
-// If the index is out of bounds, it adjusts to return appropriate slices.
-func splitRevisionsAtIndex(revisions []cappv1alpha1.CappRevision, index int) ([]cappv1alpha1.CappRevision, []cappv1alpha1.CappRevision) {
-	if index <= 0 {
-		return []cappv1alpha1.CappRevision{}, revisions
-	}
-	if index >= len(revisions) {
-		return revisions, []cappv1alpha1.CappRevision{}
-	}
-	return revisions[:index], revisions[index:]
-}
+var annotationToIgnore = cappmeta.LastUpdatedByAnnotationKey
 
 // sortByCreationTime sorts a slice of CappRevision by the CreatedAt field.
 func sortByCreationTime(cappRevisions []cappv1alpha1.CappRevision) {
@@ -67,33 +54,38 @@ func isEqual(capp cappv1alpha1.Capp, revision cappv1alpha1.CappRevision) bool {
 		equalLabels(capp.Labels, revision.Spec.CappTemplate.Labels)
 }
 
-// HandleCappUpdate manages the flow of CappRevision when a Capp is updated. It ensures that a CappRevision is created for every update.
-// It also maintains a limit of only revisionsToKeep CappRevisions in the same namespace as the Capp.
-func HandleCappUpdate(ctx context.Context, k8sClient client.Client, capp cappv1alpha1.Capp, logger logr.Logger, cappRevisions []cappv1alpha1.CappRevision) error {
+// HandleCappUpdate manages the flow of CappRevision when a Capp is updated. It ensures that a CappRevision is created
+// for every update, then prunes the revisions beyond the retention limit or period, and returns the time until the
+// next retained revision expires, or zero if none will.
+func HandleCappUpdate(ctx context.Context, k8sClient client.Client, capp cappv1alpha1.Capp, logger logr.Logger, cappRevisions []cappv1alpha1.CappRevision) (time.Duration, error) {
 	sortByCreationTime(cappRevisions)
-	numOfRevisions := len(cappRevisions)
 
-	cappConfig, err := rmanagers.GetCappConfig(ctx, k8sClient)
+	cappConfig, _, err := rmanagers.GetEffectiveCappConfig(ctx, k8sClient, capp.Namespace)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	revisionsToKeep := cappConfig.Spec.RevisionHistoryLimit
 
 	latestRevision := cappRevisions[0]
-	if isEqual(capp, latestRevision) {
-		return nil
-	}
-
-	if numOfRevisions < revisionsToKeep {
-		return adapters.CreateCappRevision(ctx, k8sClient, logger, capp, cappRevisions[0].Spec.RevisionNumber+1)
+	if !isEqual(capp, latestRevision) {
+		status, err := buildRevisionStatus(capp, &latestRevision)
+		if err != nil {
+			return 0, err
+		}
+		if err := adapters.CreateCappRevision(ctx, k8sClient, logger, capp, latestRevision.Spec.RevisionNumber+1, status); err != nil {
+			return 0, err
+		}
+		// The revision just created is the newest one, so it takes the place of the latest one in the retention.
+		created := cappv1alpha1.CappRevision{Spec: cappv1alpha1.CappRevisionSpec{
+			CappTemplate: cappv1alpha1.CappTemplate{Spec: capp.Spec, Labels: capp.Labels, Annotations: capp.Annotations},
+		}}
+		cappRevisions = append([]cappv1alpha1.CappRevision{created}, cappRevisions...)
 	}
-	relevantRevision, revisionsToDelete := splitRevisionsAtIndex(cappRevisions, revisionsToKeep-1)
 
-	for _, revision := range revisionsToDelete {
+	revisionsToPrune, nextCheck := newRevisionRetention(capp, cappConfig).revisionsToPrune(cappRevisions, time.Now())
+	for _, revision := range revisionsToPrune {
 		if err := adapters.DeleteCappRevision(ctx, k8sClient, logger, &revision); err != nil {
-			return err
+			return 0, err
 		}
 	}
-
-	return adapters.CreateCappRevision(ctx, k8sClient, logger, capp, relevantRevision[0].Spec.RevisionNumber+1)
+	return nextCheck, nil
 }
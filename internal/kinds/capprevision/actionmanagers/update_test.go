@@ -0,0 +1,96 @@
+package actionmanagers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const cappNameLabelKey = "rcs.dana.io/cappName"
+
+func newUpdateClient(limit int, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cappv1alpha1.AddToScheme(scheme))
+	cappConfig := &cappv1alpha1.CappConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: cappmeta.CappConfigName, Namespace: cappmeta.CappNS},
+		Spec:       cappv1alpha1.CappConfigSpec{RevisionHistoryLimit: limit},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, cappConfig)...).Build()
+}
+
+// storedRevisions returns revisions of capp as stored, each recording the capp as its template.
+func storedRevisions(capp cappv1alpha1.Capp, count int) []cappv1alpha1.CappRevision {
+	revisions := newRevisions(count)
+	for i := range revisions {
+		revisions[i].Namespace = capp.Namespace
+		revisions[i].Labels = map[string]string{cappNameLabelKey: capp.Name}
+		revisions[i].Spec.CappTemplate = revisionOf(capp, 0).Spec.CappTemplate
+	}
+	return revisions
+}
+
+func listRevisionNumbers(t *testing.T, c client.Client) []int {
+	list := cappv1alpha1.CappRevisionList{}
+	require.NoError(t, c.List(context.Background(), &list))
+	sortByCreationTime(list.Items)
+	return revisionNumbers(list.Items)
+}
+
+func TestHandleCappUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records a change and prunes revisions beyond the limit", func(t *testing.T) {
+		capp := newCapp()
+		revisions := storedRevisions(capp, 3)
+		objects := []client.Object{&revisions[0], &revisions[1], &revisions[2]}
+		c := newUpdateClient(3, objects...)
+
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Image = "app:v2"
+		nextCheck, err := HandleCappUpdate(ctx, c, capp, logr.Discard(), revisions)
+		require.NoError(t, err)
+		require.Zero(t, nextCheck)
+		require.ElementsMatch(t, []int{4, 3, 2}, listRevisionNumbers(t, c))
+	})
+
+	t.Run("prunes by the limit set on the Capp without a change", func(t *testing.T) {
+		capp := newCapp()
+		limit := 1
+		capp.Spec.RevisionHistoryLimit = &limit
+		revisions := storedRevisions(capp, 3)
+		objects := []client.Object{&revisions[0], &revisions[1], &revisions[2]}
+		c := newUpdateClient(10, objects...)
+
+		_, err := HandleCappUpdate(ctx, c, capp, logr.Discard(), revisions)
+		require.NoError(t, err)
+		require.Equal(t, []int{3}, listRevisionNumbers(t, c))
+	})
+
+	t.Run("requeues until the next revision expires", func(t *testing.T) {
+		capp := newCapp()
+		revisions := storedRevisions(capp, 2)
+		for i := range revisions {
+			revisions[i].CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Duration(i) * time.Hour))
+		}
+		objects := []client.Object{&revisions[0], &revisions[1]}
+		c := newUpdateClient(10, objects...)
+		cappConfig := cappv1alpha1.CappConfig{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: cappmeta.CappConfigName, Namespace: cappmeta.CappNS}, &cappConfig))
+		cappConfig.Spec.RevisionRetentionPeriod = &metav1.Duration{Duration: 2 * time.Hour}
+		require.NoError(t, c.Update(ctx, &cappConfig))
+
+		nextCheck, err := HandleCappUpdate(ctx, c, capp, logr.Discard(), revisions)
+		require.NoError(t, err)
+		require.InDelta(t, time.Hour.Seconds(), nextCheck.Seconds(), time.Minute.Seconds())
+		require.Len(t, listRevisionNumbers(t, c), 2)
+	})
+}
@@ -24,30 +24,49 @@ var (
 
 const (
 	ClientListLimit = 100
+
+	// cacheContinue is the continue token of lists read from the cache, which does not support continuations.
+	cacheContinue = "continue-not-supported"
 )
 
-// GetCappRevisions retrieves a list of CappRevision resources filtered by labels matching a specific Capp, returning the list and any error encountered.
+// GetCappRevisions retrieves the CappRevision resources labeled with a specific Capp, following list
+// continuations so that histories longer than ClientListLimit are returned in full. Lists read from the
+// cache are truncated at the limit instead of being paged, so they are read again without one.
 func GetCappRevisions(ctx context.Context, r client.Client, capp cappv1alpha1.Capp) ([]cappv1alpha1.CappRevision, error) {
-	cappRevisions := cappv1alpha1.CappRevisionList{}
-
 	requirement, err := labels.NewRequirement(cappNameLabelKey, selection.Equals, []string{capp.Name})
 	if err != nil {
-		return cappRevisions.Items, err
+		return nil, err
 	}
 
-	labelSelector := labels.NewSelector().Add(*requirement)
+	var revisions []cappv1alpha1.CappRevision
 	listOptions := client.ListOptions{
 		Namespace:     capp.Namespace,
-		LabelSelector: labelSelector,
+		LabelSelector: labels.NewSelector().Add(*requirement),
 		Limit:         ClientListLimit,
 	}
-
-	err = r.List(ctx, &cappRevisions, &listOptions)
-	return cappRevisions.Items, err
+	for {
+		cappRevisions := cappv1alpha1.CappRevisionList{}
+		if err := r.List(ctx, &cappRevisions, &listOptions); err != nil {
+			return revisions, err
+		}
+		revisions = append(revisions, cappRevisions.Items...)
+		switch cappRevisions.Continue {
+		case "":
+			return revisions, nil
+		case cacheContinue:
+			if listOptions.Limit == 0 || int64(len(cappRevisions.Items)) < listOptions.Limit {
+				return revisions, nil
+			}
+			revisions = nil
+			listOptions.Limit = 0
+		default:
+			listOptions.Continue = cappRevisions.Continue
+		}
+	}
 }
 
-// CreateCappRevision initializes and creates a CappRevision.
-func CreateCappRevision(ctx context.Context, k8sClient client.Client, logger logr.Logger, capp cappv1alpha1.Capp, revisionNumber int) error {
+// CreateCappRevision initializes and creates a CappRevision with the given status.
+func CreateCappRevision(ctx context.Context, k8sClient client.Client, logger logr.Logger, capp cappv1alpha1.Capp, revisionNumber int, status cappv1alpha1.CappRevisionStatus) error {
 	cappRevision := cappv1alpha1.CappRevision{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
@@ -63,6 +82,7 @@ func CreateCappRevision(ctx context.Context, k8sClient client.Client, logger log
 			},
 			RevisionNumber: revisionNumber,
 		},
+		Status: status,
 	}
 
 	if err := controllerutil.SetOwnerReference(&capp, &cappRevision, k8sClient.Scheme()); err != nil {
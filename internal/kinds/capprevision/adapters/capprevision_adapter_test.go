@@ -0,0 +1,94 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestGetCappRevisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(cappv1alpha1.AddToScheme(scheme))
+	capp := cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: "my-capp", Namespace: "my-ns"}}
+
+	t.Run("follows list continuations", func(t *testing.T) {
+		const pages = 3
+		var continues []string
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, _ client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOptions := client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				require.Equal(t, int64(ClientListLimit), listOptions.Limit)
+				continues = append(continues, listOptions.Continue)
+
+				page := len(continues)
+				revisions := list.(*cappv1alpha1.CappRevisionList)
+				for i := range ClientListLimit {
+					revisions.Items = append(revisions.Items, cappv1alpha1.CappRevision{
+						ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("my-capp-%d-%d", page, i)},
+					})
+				}
+				if page < pages {
+					revisions.Continue = fmt.Sprintf("page-%d", page+1)
+				}
+				return nil
+			},
+		}).Build()
+
+		revisions, err := GetCappRevisions(context.Background(), c, capp)
+		require.NoError(t, err)
+		require.Len(t, revisions, pages*ClientListLimit)
+		require.Equal(t, []string{"", "page-2", "page-3"}, continues)
+	})
+
+	t.Run("lists in full from a cache that does not support continuations", func(t *testing.T) {
+		const total = ClientListLimit + 20
+		var objects []client.Object
+		for i := range total {
+			objects = append(objects, &cappv1alpha1.CappRevision{ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("my-capp-%05d", i),
+				Namespace: capp.Namespace,
+				Labels:    map[string]string{cappNameLabelKey: capp.Name},
+			}})
+		}
+
+		// The interceptor behaves like the cache reader: it rejects continuations and truncates lists at
+		// the limit.
+		lists := 0
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, w client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists++
+				listOptions := client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				if listOptions.Continue != "" {
+					return fmt.Errorf("continue list option is not supported by the cache")
+				}
+				limit := listOptions.Limit
+				listOptions.Limit = 0
+				if err := w.List(ctx, list, &listOptions); err != nil {
+					return err
+				}
+				revisions := list.(*cappv1alpha1.CappRevisionList)
+				if limit > 0 && int64(len(revisions.Items)) > limit {
+					revisions.Items = revisions.Items[:limit]
+				}
+				revisions.Continue = cacheContinue
+				return nil
+			},
+		}).Build()
+
+		revisions, err := GetCappRevisions(context.Background(), c, capp)
+		require.NoError(t, err)
+		require.Len(t, revisions, total)
+		require.Equal(t, 2, lists)
+	})
+}
@@ -69,26 +69,32 @@ func (r *CappRevisionReconciler) Reconcile(ctx context.Context, req reconcile.Re
 	if !capp.DeletionTimestamp.IsZero() {
 		return ctrl.Result{}, nil
 	}
-	if err := syncCappRevision(ctx, r.Client, capp, logger); err != nil {
+	if capp.Spec.Paused {
+		logger.Info("Capp is paused, skipping CappRevisions")
+		return ctrl.Result{}, nil
+	}
+	nextCheck, err := syncCappRevision(ctx, r.Client, capp, logger)
+	if err != nil {
 		if errors.IsConflict(err) || errors.IsAlreadyExists(err) {
 			logger.Info(fmt.Sprintf("Conflict detected requeuing: %s", err.Error()))
 			return ctrl.Result{RequeueAfter: RequeueTime}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to sync Capp: %s", err.Error())
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: nextCheck}, nil
 }
 
 // syncCappRevision manages the lifecycle of CappRevisions based on the state of a Capp, handling creation, update, or deletion.
-func syncCappRevision(ctx context.Context, k8sClient client.Client, capp cappv1alpha1.Capp, logger logr.Logger) error {
+// It returns the time after which revisions should be checked again for expiry, or zero if they need not be.
+func syncCappRevision(ctx context.Context, k8sClient client.Client, capp cappv1alpha1.Capp, logger logr.Logger) (time.Duration, error) {
 	cappRevisions, err := adapters.GetCappRevisions(ctx, k8sClient, capp)
 	if err != nil {
 		logger.Error(err, "could not fetch cappRevisions")
-		return err
+		return 0, err
 	}
 
 	if len(cappRevisions) == 0 {
-		return actionmanagers.HandleCappCreation(ctx, k8sClient, capp, logger)
+		return 0, actionmanagers.HandleCappCreation(ctx, k8sClient, capp, logger)
 	}
 
 	return actionmanagers.HandleCappUpdate(ctx, k8sClient, capp, logger, cappRevisions)
@@ -0,0 +1,61 @@
+package kubectlcapp
+
+import (
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	cappName      = "my-capp"
+	cappNamespace = "my-ns"
+	cappNameLabel = "rcs.dana.io/cappName"
+	containerName = "app"
+)
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(cappv1alpha1.AddToScheme(s))
+	return s
+}
+
+func newFakeClient(objects ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+}
+
+func newCapp() *cappv1alpha1.Capp {
+	return &cappv1alpha1.Capp{
+		ObjectMeta: metav1.ObjectMeta{Name: cappName, Namespace: cappNamespace},
+		Spec:       cappv1alpha1.CappSpec{State: cappv1alpha1.CappStateEnabled},
+	}
+}
+
+func newRevision(number int, image string) *cappv1alpha1.CappRevision {
+	revision := &cappv1alpha1.CappRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%05d", cappName, number),
+			Namespace: cappNamespace,
+			Labels:    map[string]string{cappNameLabel: cappName},
+		},
+		Spec: cappv1alpha1.CappRevisionSpec{
+			RevisionNumber: number,
+			CappTemplate: cappv1alpha1.CappTemplate{
+				Labels:      map[string]string{"revision": fmt.Sprint(number)},
+				Annotations: map[string]string{"image": image},
+				Spec:        cappv1alpha1.CappSpec{State: cappv1alpha1.CappStateEnabled},
+			},
+		},
+	}
+	revision.Spec.CappTemplate.Spec.ConfigurationSpec.Template.Spec.Containers = containersWithImage(image)
+	return revision
+}
+
+func containersWithImage(image string) []corev1.Container {
+	return []corev1.Container{{Name: containerName, Image: image}}
+}
@@ -0,0 +1,114 @@
+package kubectlcapp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+// defaultUserContainerName is the name Knative gives to an unnamed container of a revision.
+const defaultUserContainerName = "user-container"
+
+// LogOptions configure which logs StreamLogs prints.
+type LogOptions struct {
+	// Container is the container to print logs from. It defaults to the first container of the Capp.
+	Container string
+	// Follow keeps streaming new log lines until ctx is done.
+	Follow bool
+	// TailLines limits the number of past lines printed per pod, if set.
+	TailLines *int64
+}
+
+// CurrentRevision returns the name of the Knative revision serving capp: its latest ready revision,
+// or its latest created one while none is ready.
+func CurrentRevision(capp cappv1alpha1.Capp) (string, error) {
+	knativeStatus := capp.Status.KnativeObjectStatus
+	if knativeStatus.LatestReadyRevisionName != "" {
+		return knativeStatus.LatestReadyRevisionName, nil
+	}
+	if knativeStatus.LatestCreatedRevisionName != "" {
+		return knativeStatus.LatestCreatedRevisionName, nil
+	}
+	return "", fmt.Errorf("capp %q has no Knative revision yet", capp.Name)
+}
+
+// StreamLogs writes the logs of the pods of the current Knative revision of capp to out,
+// prefixing each line with the name of its pod.
+func StreamLogs(ctx context.Context, clientset kubernetes.Interface, out io.Writer, capp cappv1alpha1.Capp, opts LogOptions) error {
+	revision, err := CurrentRevision(capp)
+	if err != nil {
+		return err
+	}
+
+	pods, err := clientset.CoreV1().Pods(capp.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: serving.RevisionLabelKey + "=" + revision,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods of revision %q: %w", revision, err)
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("revision %q has no pods; it may be scaled to zero", revision)
+	}
+
+	container := opts.Container
+	if container == "" {
+		container = userContainerName(capp)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make([]error, len(pods.Items))
+	)
+	for i, pod := range pods.Items {
+		wg.Go(func() {
+			errs[i] = streamPodLogs(ctx, clientset, out, &mu, pod, container, opts)
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func streamPodLogs(ctx context.Context, clientset kubernetes.Interface, out io.Writer, mu *sync.Mutex, pod corev1.Pod, container string, opts LogOptions) error {
+	stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: container,
+		Follow:    opts.Follow,
+		TailLines: opts.TailLines,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stream logs of pod %q: %w", pod.Name, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		mu.Lock()
+		_, err := fmt.Fprintf(out, "[%s] %s\n", pod.Name, scanner.Text())
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs of pod %q: %w", pod.Name, err)
+	}
+	return nil
+}
+
+func userContainerName(capp cappv1alpha1.Capp) string {
+	containers := capp.Spec.ConfigurationSpec.Template.Spec.Containers
+	if len(containers) > 0 && containers[0].Name != "" {
+		return containers[0].Name
+	}
+	return defaultUserContainerName
+}
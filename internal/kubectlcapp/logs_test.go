@@ -0,0 +1,83 @@
+package kubectlcapp
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+func newRevisionPod(name, revision string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: cappNamespace,
+		Labels:    map[string]string{serving.RevisionLabelKey: revision},
+	}}
+}
+
+func TestCurrentRevision(t *testing.T) {
+	t.Run("prefers the latest ready revision", func(t *testing.T) {
+		capp := newCapp()
+		capp.Status.KnativeObjectStatus.LatestReadyRevisionName = "my-capp-00001"
+		capp.Status.KnativeObjectStatus.LatestCreatedRevisionName = "my-capp-00002"
+
+		revision, err := CurrentRevision(*capp)
+		require.NoError(t, err)
+		require.Equal(t, "my-capp-00001", revision)
+	})
+
+	t.Run("falls back to the latest created revision", func(t *testing.T) {
+		capp := newCapp()
+		capp.Status.KnativeObjectStatus.LatestCreatedRevisionName = "my-capp-00002"
+
+		revision, err := CurrentRevision(*capp)
+		require.NoError(t, err)
+		require.Equal(t, "my-capp-00002", revision)
+	})
+
+	t.Run("fails without revisions", func(t *testing.T) {
+		_, err := CurrentRevision(*newCapp())
+		require.ErrorContains(t, err, "has no Knative revision yet")
+	})
+}
+
+func TestStreamLogs(t *testing.T) {
+	ctx := context.Background()
+	capp := newCapp()
+	capp.Status.KnativeObjectStatus.LatestReadyRevisionName = "my-capp-00002"
+
+	t.Run("prints the logs of every pod of the current revision", func(t *testing.T) {
+		clientset := kubefake.NewClientset(
+			newRevisionPod("pod-a", "my-capp-00002"),
+			newRevisionPod("pod-b", "my-capp-00002"),
+			newRevisionPod("pod-old", "my-capp-00001"),
+		)
+
+		out := bytes.Buffer{}
+		require.NoError(t, StreamLogs(ctx, clientset, &out, *capp, LogOptions{}))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.ElementsMatch(t, []string{"[pod-a] fake logs", "[pod-b] fake logs"}, lines)
+	})
+
+	t.Run("fails when the revision has no pods", func(t *testing.T) {
+		clientset := kubefake.NewClientset(newRevisionPod("pod-old", "my-capp-00001"))
+
+		err := StreamLogs(ctx, clientset, &bytes.Buffer{}, *capp, LogOptions{})
+		require.ErrorContains(t, err, "has no pods")
+	})
+}
+
+func TestUserContainerName(t *testing.T) {
+	capp := newCapp()
+	require.Equal(t, defaultUserContainerName, userContainerName(*capp))
+
+	capp.Spec.ConfigurationSpec.Template.Spec.Containers = containersWithImage("app:v1")
+	require.Equal(t, containerName, userContainerName(*capp))
+}
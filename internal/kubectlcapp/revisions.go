@@ -0,0 +1,85 @@
+package kubectlcapp
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capprevision/adapters"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ListRevisions returns the CappRevisions of the named Capp, newest first.
+func ListRevisions(ctx context.Context, c client.Client, namespace, name string) ([]cappv1alpha1.CappRevision, error) {
+	capp := cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	revisions, err := adapters.GetCappRevisions(ctx, c, capp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CappRevisions of Capp %q: %w", name, err)
+	}
+	slices.SortFunc(revisions, func(a, b cappv1alpha1.CappRevision) int {
+		return cmp.Compare(b.Spec.RevisionNumber, a.Spec.RevisionNumber)
+	})
+	return revisions, nil
+}
+
+// FindRevision returns the revision with the given number.
+func FindRevision(revisions []cappv1alpha1.CappRevision, number int) (cappv1alpha1.CappRevision, error) {
+	for _, revision := range revisions {
+		if revision.Spec.RevisionNumber == number {
+			return revision, nil
+		}
+	}
+	return cappv1alpha1.CappRevision{}, fmt.Errorf("revision %d not found", number)
+}
+
+// PrintRevisions writes a table of revisions to out.
+func PrintRevisions(out io.Writer, revisions []cappv1alpha1.CappRevision, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "REVISION\tNAME\tUPDATED BY\tCHANGES\tAGE"); err != nil {
+		return err
+	}
+	for _, revision := range revisions {
+		age := "<unknown>"
+		if !revision.CreationTimestamp.IsZero() {
+			age = duration.HumanDuration(now.Sub(revision.CreationTimestamp.Time))
+		}
+		updatedBy := revision.Status.UpdatedBy
+		if updatedBy == "" {
+			updatedBy = "<unknown>"
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", revision.Spec.RevisionNumber, revision.Name, updatedBy,
+			len(revision.Status.ChangedFields), age); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// DiffRevisions writes a unified diff of the Capp templates recorded in two revisions to out.
+func DiffRevisions(out io.Writer, from, to cappv1alpha1.CappRevision) error {
+	fromYAML, err := yaml.Marshal(from.Spec.CappTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revision %d: %w", from.Spec.RevisionNumber, err)
+	}
+	toYAML, err := yaml.Marshal(to.Spec.CappTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal revision %d: %w", to.Spec.RevisionNumber, err)
+	}
+
+	return difflib.WriteUnifiedDiff(out, difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(fromYAML)),
+		B:        difflib.SplitLines(string(toYAML)),
+		FromFile: fmt.Sprintf("revision %d", from.Spec.RevisionNumber),
+		ToFile:   fmt.Sprintf("revision %d", to.Spec.RevisionNumber),
+		Context:  3,
+	})
+}
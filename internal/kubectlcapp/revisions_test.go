@@ -0,0 +1,81 @@
+package kubectlcapp
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListRevisions(t *testing.T) {
+	t.Run("returns the revisions of the capp newest first", func(t *testing.T) {
+		other := newRevision(7, "other:v1")
+		other.Name = "other-00007"
+		other.Labels[cappNameLabel] = "other"
+		c := newFakeClient(newRevision(1, "app:v1"), newRevision(3, "app:v3"), newRevision(2, "app:v2"), other)
+
+		revisions, err := ListRevisions(context.Background(), c, cappNamespace, cappName)
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+		require.Equal(t, 3, revisions[0].Spec.RevisionNumber)
+		require.Equal(t, 2, revisions[1].Spec.RevisionNumber)
+		require.Equal(t, 1, revisions[2].Spec.RevisionNumber)
+	})
+}
+
+func TestFindRevision(t *testing.T) {
+	revisions, err := ListRevisions(context.Background(), newFakeClient(newRevision(1, "app:v1"), newRevision(2, "app:v2")), cappNamespace, cappName)
+	require.NoError(t, err)
+
+	t.Run("returns the revision with the number", func(t *testing.T) {
+		revision, err := FindRevision(revisions, 1)
+		require.NoError(t, err)
+		require.Equal(t, "my-capp-00001", revision.Name)
+	})
+
+	t.Run("fails for an unknown number", func(t *testing.T) {
+		_, err := FindRevision(revisions, 5)
+		require.ErrorContains(t, err, "revision 5 not found")
+	})
+}
+
+func TestPrintRevisions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	revision := newRevision(2, "app:v2")
+	revision.CreationTimestamp = metav1.NewTime(now.Add(-90 * time.Minute))
+	revision.Status.UpdatedBy = "alice"
+	revision.Status.ChangedFields = []string{"spec.scaleSpec.minReplicas", "spec.routeSpec.hostname"}
+
+	out := bytes.Buffer{}
+	require.NoError(t, PrintRevisions(&out, []cappv1alpha1.CappRevision{*revision, *newRevision(1, "app:v1")}, now))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"REVISION", "NAME", "UPDATED", "BY", "CHANGES", "AGE"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"2", "my-capp-00002", "alice", "2", "90m"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"1", "my-capp-00001", "<unknown>", "0", "<unknown>"}, strings.Fields(lines[2]))
+}
+
+func TestDiffRevisions(t *testing.T) {
+	t.Run("shows the changed fields of the capp template", func(t *testing.T) {
+		out := bytes.Buffer{}
+		require.NoError(t, DiffRevisions(&out, *newRevision(1, "app:v1"), *newRevision(2, "app:v2")))
+
+		diff := out.String()
+		require.Contains(t, diff, "--- revision 1\n+++ revision 2\n")
+		require.Contains(t, diff, "-  image: app:v1\n+  image: app:v2\n")
+		require.Contains(t, diff, "-        - image: app:v1\n+        - image: app:v2\n")
+		require.Contains(t, diff, "-  revision: \"1\"\n+  revision: \"2\"\n")
+	})
+
+	t.Run("prints nothing for identical templates", func(t *testing.T) {
+		out := bytes.Buffer{}
+		require.NoError(t, DiffRevisions(&out, *newRevision(1, "app:v1"), *newRevision(1, "app:v1")))
+		require.Empty(t, out.String())
+	})
+}
@@ -0,0 +1,49 @@
+package kubectlcapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Rollback restores the spec, labels and annotations of the named Capp from one of its CappRevisions
+// and returns the number of the revision restored. A toRevision of 0 restores the revision before the
+// latest one. The operator records the restored Capp as a new revision.
+func Rollback(ctx context.Context, c client.Client, namespace, name string, toRevision int) (int, error) {
+	revisions, err := ListRevisions(ctx, c, namespace, name)
+	if err != nil {
+		return 0, err
+	}
+
+	var revision cappv1alpha1.CappRevision
+	if toRevision == 0 {
+		if len(revisions) < 2 {
+			return 0, errors.New("no previous revision to roll back to")
+		}
+		revision = revisions[1]
+	} else if revision, err = FindRevision(revisions, toRevision); err != nil {
+		return 0, err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		capp := cappv1alpha1.Capp{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &capp); err != nil {
+			return err
+		}
+		template := revision.Spec.CappTemplate
+		capp.Spec = *template.Spec.DeepCopy()
+		capp.Labels = maps.Clone(template.Labels)
+		capp.Annotations = maps.Clone(template.Annotations)
+		return c.Update(ctx, &capp)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll back Capp %q to revision %d: %w", name, revision.Spec.RevisionNumber, err)
+	}
+	return revision.Spec.RevisionNumber, nil
+}
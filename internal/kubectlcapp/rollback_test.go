@@ -0,0 +1,67 @@
+package kubectlcapp
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: cappNamespace, Name: cappName}
+
+	newCappAtRevision := func(revision *cappv1alpha1.CappRevision) *cappv1alpha1.Capp {
+		capp := newCapp()
+		capp.Spec = revision.Spec.CappTemplate.Spec
+		capp.Labels = revision.Spec.CappTemplate.Labels
+		capp.Annotations = revision.Spec.CappTemplate.Annotations
+		return capp
+	}
+
+	t.Run("restores the revision before the latest one by default", func(t *testing.T) {
+		latest := newRevision(3, "app:v3")
+		c := newFakeClient(newCappAtRevision(latest), newRevision(1, "app:v1"), newRevision(2, "app:v2"), latest)
+
+		restored, err := Rollback(ctx, c, cappNamespace, cappName, 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, restored)
+
+		capp := cappv1alpha1.Capp{}
+		require.NoError(t, c.Get(ctx, key, &capp))
+		require.Equal(t, "app:v2", capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Image)
+		require.Equal(t, map[string]string{"revision": "2"}, capp.Labels)
+		require.Equal(t, map[string]string{"image": "app:v2"}, capp.Annotations)
+	})
+
+	t.Run("restores the requested revision", func(t *testing.T) {
+		latest := newRevision(3, "app:v3")
+		c := newFakeClient(newCappAtRevision(latest), newRevision(1, "app:v1"), newRevision(2, "app:v2"), latest)
+
+		restored, err := Rollback(ctx, c, cappNamespace, cappName, 1)
+		require.NoError(t, err)
+		require.Equal(t, 1, restored)
+
+		capp := cappv1alpha1.Capp{}
+		require.NoError(t, c.Get(ctx, key, &capp))
+		require.Equal(t, "app:v1", capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].Image)
+	})
+
+	t.Run("fails without a previous revision", func(t *testing.T) {
+		latest := newRevision(1, "app:v1")
+		c := newFakeClient(newCappAtRevision(latest), latest)
+
+		_, err := Rollback(ctx, c, cappNamespace, cappName, 0)
+		require.ErrorContains(t, err, "no previous revision")
+	})
+
+	t.Run("fails for an unknown revision", func(t *testing.T) {
+		latest := newRevision(1, "app:v1")
+		c := newFakeClient(newCappAtRevision(latest), latest)
+
+		_, err := Rollback(ctx, c, cappNamespace, cappName, 4)
+		require.ErrorContains(t, err, "revision 4 not found")
+	})
+}
@@ -0,0 +1,28 @@
+package kubectlcapp
+
+import (
+	"context"
+	"fmt"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetState sets the state of the named Capp to state, which is either enabled or disabled.
+func SetState(ctx context.Context, c client.Client, namespace, name, state string) error {
+	capp := cappv1alpha1.Capp{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &capp); err != nil {
+		return err
+	}
+	if capp.Spec.State == state {
+		return nil
+	}
+
+	patch := client.MergeFrom(capp.DeepCopy())
+	capp.Spec.State = state
+	if err := c.Patch(ctx, &capp, patch); err != nil {
+		return fmt.Errorf("failed to set state of Capp %q to %s: %w", name, state, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package kubectlcapp
+
+import (
+	"context"
+	"testing"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSetState(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: cappNamespace, Name: cappName}
+
+	t.Run("disables and enables the capp", func(t *testing.T) {
+		c := newFakeClient(newCapp())
+
+		require.NoError(t, SetState(ctx, c, cappNamespace, cappName, cappv1alpha1.CappStateDisabled))
+		capp := cappv1alpha1.Capp{}
+		require.NoError(t, c.Get(ctx, key, &capp))
+		require.Equal(t, cappv1alpha1.CappStateDisabled, capp.Spec.State)
+
+		require.NoError(t, SetState(ctx, c, cappNamespace, cappName, cappv1alpha1.CappStateEnabled))
+		require.NoError(t, c.Get(ctx, key, &capp))
+		require.Equal(t, cappv1alpha1.CappStateEnabled, capp.Spec.State)
+	})
+
+	t.Run("fails for a missing capp", func(t *testing.T) {
+		err := SetState(ctx, newFakeClient(), cappNamespace, cappName, cappv1alpha1.CappStateDisabled)
+		require.True(t, errors.IsNotFound(err))
+	})
+}
@@ -0,0 +1,194 @@
+package kubectlcapp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kapis "knative.dev/pkg/apis"
+)
+
+const (
+	readyUnknown = "Unknown"
+
+	treeBranch     = "├── "
+	treeLastBranch = "└── "
+	treeIndent     = "│   "
+	treeLastIndent = "    "
+)
+
+// statusNode is a resource shown in the status tree, with the readiness the Capp status reports for it.
+type statusNode struct {
+	kind     string
+	name     string
+	ready    string
+	reason   string
+	children []statusNode
+}
+
+// PrintStatus writes a tree of capp and its child resources to out, with the readiness and the
+// reason reported for each of them in the Capp status.
+func PrintStatus(out io.Writer, capp cappv1alpha1.Capp) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAME\tREADY\tREASON"); err != nil {
+		return err
+	}
+	if err := printNode(w, buildStatusTree(capp), "", ""); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func printNode(w io.Writer, node statusNode, prefix, childPrefix string) error {
+	if _, err := fmt.Fprintf(w, "%s%s/%s\t%s\t%s\n", prefix, node.kind, node.name, node.ready, node.reason); err != nil {
+		return err
+	}
+	for i, child := range node.children {
+		branch, indent := treeBranch, treeIndent
+		if i == len(node.children)-1 {
+			branch, indent = treeLastBranch, treeLastIndent
+		}
+		if err := printNode(w, child, childPrefix+branch, childPrefix+indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildStatusTree returns the Capp and the child resources its spec requires, in the order the
+// resource managers create them.
+func buildStatusTree(capp cappv1alpha1.Capp) statusNode {
+	status := capp.Status
+	root := statusNode{kind: "Capp", name: capp.Name, ready: readyUnknown}
+	if condition := meta.FindStatusCondition(status.Conditions, cappv1alpha1.CappConditionReady); condition != nil {
+		root.ready, root.reason = string(condition.Status), condition.Reason
+	}
+
+	if (rmanagers.KnativeServiceManager{}).IsRequired(capp) {
+		root.children = append(root.children, knativeServiceNode(capp))
+	}
+
+	if (rmanagers.NFSPVCManager{}).IsRequired(capp) {
+		for _, volume := range status.VolumesStatus.NFSVolumesStatus {
+			node := statusNode{kind: "NfsPvc", name: volume.VolumeName, ready: readyUnknown}
+			if volume.NFSPVCStatus.PvcPhase != "" {
+				node.ready = string(metav1.ConditionFalse)
+				if volume.NFSPVCStatus.PvcPhase == string(corev1.ClaimBound) {
+					node.ready = string(metav1.ConditionTrue)
+				}
+				node.reason = volume.NFSPVCStatus.PvcPhase
+			}
+			if volume.Phase != "" {
+				node.reason = string(volume.Phase)
+			}
+			root.children = append(root.children, node)
+		}
+	}
+
+	for _, volume := range status.VolumesStatus.PVCVolumesStatus {
+		node := statusNode{kind: "PersistentVolumeClaim", name: volume.ClaimName, ready: readyUnknown}
+		if volume.Phase != "" {
+			node.ready = string(metav1.ConditionFalse)
+			if volume.Phase == corev1.ClaimBound {
+				node.ready = string(metav1.ConditionTrue)
+			}
+			node.reason = string(volume.Phase)
+		}
+		root.children = append(root.children, node)
+	}
+
+	for _, secret := range status.SecretsStatus {
+		node := statusNode{kind: "ExternalSecret", name: secret.SecretName, ready: string(metav1.ConditionFalse), reason: "NotSynced"}
+		if secret.Synced {
+			node.ready, node.reason = string(metav1.ConditionTrue), "Synced"
+		}
+		root.children = append(root.children, node)
+	}
+
+	if (rmanagers.SyslogNGOutputManager{}).IsRequired(capp) {
+		logging := status.LoggingStatus
+		root.children = append(root.children,
+			loggingNode("SyslogNGOutput", capp.Name, logging.SyslogNGOutput.Active, logging.SyslogNGOutput.ProblemsCount),
+			loggingNode("SyslogNGFlow", capp.Name, logging.SyslogNGFlow.Active, logging.SyslogNGFlow.ProblemsCount))
+	}
+
+	routeName := routeResourceName(capp)
+	route := status.RouteStatus
+	if (rmanagers.CertificateManager{}).IsRequired(capp) {
+		node := statusNode{kind: "Certificate", name: routeName, ready: readyUnknown}
+		for _, condition := range route.CertificateObjectStatus.Conditions {
+			if string(condition.Type) == string(kapis.ConditionReady) {
+				node.ready, node.reason = string(condition.Status), condition.Reason
+			}
+		}
+		root.children = append(root.children, node)
+	}
+
+	if (rmanagers.DomainMappingManager{}).IsRequired(capp) {
+		root.children = append(root.children, knativeConditionNode("DomainMapping", routeName,
+			route.DomainMappingObjectStatus.GetCondition(kapis.ConditionReady)))
+	}
+
+	if (rmanagers.DNSRecordManager{}).IsRequired(capp) {
+		node := statusNode{kind: "CNAMERecord", name: routeName, ready: readyUnknown}
+		if condition := route.DNSRecordObjectStatus.CNAMERecordObjectStatus.GetCondition(xpv1.TypeReady); condition.Status != "" {
+			node.ready, node.reason = string(condition.Status), string(condition.Reason)
+		}
+		root.children = append(root.children, node)
+	}
+
+	for _, source := range status.EventingStatus.EventSources {
+		condition := source.Condition
+		root.children = append(root.children, knativeConditionNode("EventSource", source.Name, &condition))
+	}
+
+	return root
+}
+
+func knativeServiceNode(capp cappv1alpha1.Capp) statusNode {
+	knativeStatus := capp.Status.KnativeObjectStatus
+	node := knativeConditionNode("Service", capp.Name, knativeStatus.GetCondition(kapis.ConditionReady))
+	for _, revision := range capp.Status.RevisionInfo {
+		node.children = append(node.children, knativeConditionNode("Revision", revision.RevisionName,
+			revision.RevisionStatus.GetCondition(kapis.ConditionReady)))
+	}
+	return node
+}
+
+func knativeConditionNode(kind, name string, condition *kapis.Condition) statusNode {
+	node := statusNode{kind: kind, name: name, ready: readyUnknown}
+	if condition != nil && condition.Status != "" {
+		node.ready, node.reason = string(condition.Status), condition.Reason
+	}
+	return node
+}
+
+func loggingNode(kind, name string, active *bool, problemsCount int) statusNode {
+	node := statusNode{kind: kind, name: name, ready: readyUnknown}
+	switch {
+	case problemsCount > 0:
+		node.ready, node.reason = string(metav1.ConditionFalse), fmt.Sprintf("%d problems", problemsCount)
+	case active != nil && *active:
+		node.ready = string(metav1.ConditionTrue)
+	case active != nil:
+		node.ready, node.reason = string(metav1.ConditionFalse), "Inactive"
+	}
+	return node
+}
+
+// routeResourceName returns the name of the Certificate, DomainMapping and CNAMERecord of capp,
+// using the DNS zone of the CappConfig in effect for it when the status records one.
+func routeResourceName(capp cappv1alpha1.Capp) string {
+	hostname := capp.Spec.RouteSpec.Hostname
+	if config := capp.Status.ConfigStatus.EffectiveConfig; config != nil && config.DNSConfig.Zone != "" {
+		return rmanagers.GenerateResourceName(hostname, config.DNSConfig.Zone)
+	}
+	return strings.TrimSuffix(hostname, ".")
+}
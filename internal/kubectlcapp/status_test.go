@@ -0,0 +1,102 @@
+package kubectlcapp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kapis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func readyStatus(status corev1.ConditionStatus, reason string) duckv1.Status {
+	return duckv1.Status{Conditions: duckv1.Conditions{{Type: kapis.ConditionReady, Status: status, Reason: reason}}}
+}
+
+func TestPrintStatus(t *testing.T) {
+	t.Run("prints the capp with its knative service and revisions", func(t *testing.T) {
+		capp := newCapp()
+		capp.Status.Conditions = []metav1.Condition{{
+			Type: cappv1alpha1.CappConditionReady, Status: metav1.ConditionFalse, Reason: cappv1alpha1.CappReadyReasonKnativeNotReady,
+		}}
+		capp.Status.KnativeObjectStatus.Status = readyStatus(corev1.ConditionFalse, "RevisionFailed")
+		capp.Status.RevisionInfo = []cappv1alpha1.RevisionInfo{
+			{RevisionName: "my-capp-00002"},
+			{RevisionName: "my-capp-00001"},
+		}
+		capp.Status.RevisionInfo[0].RevisionStatus.Status = readyStatus(corev1.ConditionFalse, "ContainerMissing")
+		capp.Status.RevisionInfo[1].RevisionStatus.Status = readyStatus(corev1.ConditionTrue, "")
+
+		out := bytes.Buffer{}
+		require.NoError(t, PrintStatus(&out, *capp))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 5)
+		require.Equal(t, []string{"NAME", "READY", "REASON"}, strings.Fields(lines[0]))
+		require.Equal(t, []string{"Capp/my-capp", "False", "KnativeServiceNotReady"}, strings.Fields(lines[1]))
+		require.Equal(t, []string{"└──", "Service/my-capp", "False", "RevisionFailed"}, strings.Fields(lines[2]))
+		require.Equal(t, []string{"├──", "Revision/my-capp-00002", "False", "ContainerMissing"}, strings.Fields(lines[3]))
+		require.Equal(t, []string{"└──", "Revision/my-capp-00001", "True"}, strings.Fields(lines[4]))
+	})
+
+	t.Run("prints route resources named after the effective DNS zone", func(t *testing.T) {
+		capp := newCapp()
+		capp.Spec.State = cappv1alpha1.CappStateDisabled
+		capp.Spec.RouteSpec.Hostname = "my-app"
+		capp.Spec.RouteSpec.TlsEnabled = true
+		capp.Status.ConfigStatus.EffectiveConfig = &cappv1alpha1.CappConfigSpec{
+			DNSConfig: cappv1alpha1.DNSConfig{Zone: "capp-zone.com."},
+		}
+		capp.Status.RouteStatus.CertificateObjectStatus.Conditions = []cmapi.CertificateCondition{{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, Reason: "Ready",
+		}}
+
+		out := bytes.Buffer{}
+		require.NoError(t, PrintStatus(&out, *capp))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 5)
+		require.Equal(t, []string{"Capp/my-capp", "Unknown"}, strings.Fields(lines[1]))
+		require.Equal(t, []string{"├──", "Certificate/my-app.capp-zone.com", "True", "Ready"}, strings.Fields(lines[2]))
+		require.Equal(t, []string{"├──", "DomainMapping/my-app.capp-zone.com", "Unknown"}, strings.Fields(lines[3]))
+		require.Equal(t, []string{"└──", "CNAMERecord/my-app.capp-zone.com", "Unknown"}, strings.Fields(lines[4]))
+	})
+
+	t.Run("prints volumes, logging and event sources", func(t *testing.T) {
+		active := true
+		capp := newCapp()
+		capp.Spec.State = cappv1alpha1.CappStateDisabled
+		capp.Spec.VolumesSpec.NFSVolumes = []cappv1alpha1.NFSVolume{{Name: "data"}}
+		capp.Spec.LogSpec.Type = cappv1alpha1.LogTypeElastic
+		capp.Status.VolumesStatus.NFSVolumesStatus = []cappv1alpha1.NFSVolumeStatus{{VolumeName: "data"}}
+		capp.Status.VolumesStatus.NFSVolumesStatus[0].NFSPVCStatus.PvcPhase = string(corev1.ClaimPending)
+		capp.Status.VolumesStatus.PVCVolumesStatus = []cappv1alpha1.PVCVolumeStatus{
+			{VolumeName: "cache", ClaimName: "my-capp-cache", Phase: corev1.ClaimBound},
+		}
+		capp.Status.SecretsStatus = []cappv1alpha1.ExternalSecretStatus{{Name: "db", SecretName: "my-capp-db"}}
+		capp.Status.LoggingStatus.SyslogNGOutput.Active = &active
+		capp.Status.LoggingStatus.SyslogNGFlow.ProblemsCount = 2
+		capp.Status.EventingStatus.EventSources = []cappv1alpha1.EventSourceStatus{{
+			Name:      "orders",
+			Condition: kapis.Condition{Type: kapis.ConditionReady, Status: corev1.ConditionTrue},
+		}}
+
+		out := bytes.Buffer{}
+		require.NoError(t, PrintStatus(&out, *capp))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 8)
+		require.Equal(t, []string{"├──", "NfsPvc/data", "False", "Pending"}, strings.Fields(lines[2]))
+		require.Equal(t, []string{"├──", "PersistentVolumeClaim/my-capp-cache", "True", "Bound"}, strings.Fields(lines[3]))
+		require.Equal(t, []string{"├──", "ExternalSecret/my-capp-db", "False", "NotSynced"}, strings.Fields(lines[4]))
+		require.Equal(t, []string{"├──", "SyslogNGOutput/my-capp", "True"}, strings.Fields(lines[5]))
+		require.Equal(t, []string{"├──", "SyslogNGFlow/my-capp", "False", "2", "problems"}, strings.Fields(lines[6]))
+		require.Equal(t, []string{"└──", "EventSource/orders", "True"}, strings.Fields(lines[7]))
+	})
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const unknownReadyReason = "Unknown"
+
+var (
+	cappsByReadyReasonDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "capps_by_ready_reason"),
+		"Number of Capps by the reason of their Ready condition.",
+		[]string{"reason"}, nil,
+	)
+	cappsByStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "capps_by_state"),
+		"Number of Capps by state.",
+		[]string{"state"}, nil,
+	)
+	cappsByScaleMetricDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "capps_by_scale_metric"),
+		"Number of Capps by scale metric.",
+		[]string{"metric"}, nil,
+	)
+)
+
+// CappCollector reports the number of Capps by Ready reason, state and scale metric.
+// The Capps are listed from the given reader, normally the manager's cache, on every scrape.
+type CappCollector struct {
+	Reader client.Reader
+}
+
+// NewCappCollector returns a CappCollector listing Capps from the given reader.
+func NewCappCollector(reader client.Reader) *CappCollector {
+	return &CappCollector{Reader: reader}
+}
+
+// Describe implements prometheus.Collector.
+func (c *CappCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cappsByReadyReasonDesc
+	ch <- cappsByStateDesc
+	ch <- cappsByScaleMetricDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *CappCollector) Collect(ch chan<- prometheus.Metric) {
+	capps := cappv1alpha1.CappList{}
+	if err := c.Reader.List(context.Background(), &capps); err != nil {
+		ch <- prometheus.NewInvalidMetric(cappsByReadyReasonDesc, err)
+		return
+	}
+
+	byReadyReason := map[string]int{}
+	byState := map[string]int{}
+	byScaleMetric := map[string]int{}
+	for _, capp := range capps.Items {
+		reason := unknownReadyReason
+		if cond := meta.FindStatusCondition(capp.Status.Conditions, cappv1alpha1.CappConditionReady); cond != nil {
+			reason = cond.Reason
+		}
+		byReadyReason[reason]++
+		byState[capp.Spec.State]++
+		byScaleMetric[capp.Spec.ScaleSpec.Metric]++
+	}
+
+	sendCounts(ch, cappsByReadyReasonDesc, byReadyReason)
+	sendCounts(ch, cappsByStateDesc, byState)
+	sendCounts(ch, cappsByScaleMetricDesc, byScaleMetric)
+}
+
+func sendCounts(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[string]int) {
+	for label, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), label)
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "capp"
+
+	resultSuccess = "success"
+	resultError   = "error"
+
+	// OperationCreate, OperationUpdate, OperationApply and OperationDelete are the child resource
+	// operations counted by RecordChildResourceOperation.
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationApply  = "apply"
+	OperationDelete = "delete"
+
+	// DriftActionCorrected and DriftActionPaused are the outcomes of a drift counted by RecordDrift.
+	DriftActionCorrected = "corrected"
+	DriftActionPaused    = "paused"
+)
+
+var (
+	resourceManagerSyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "resource_manager",
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of a resource manager sync of a single Capp, by resource manager.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"manager"})
+
+	resourceManagerSyncErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "resource_manager",
+		Name:      "sync_errors_total",
+		Help:      "Number of failed resource manager syncs, by resource manager.",
+	}, []string{"manager"})
+
+	childResourceOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "child_resource",
+		Name:      "operations_total",
+		Help:      "Number of create, update, apply and delete calls made for Capp child resources, by kind, operation and result.",
+	}, []string{"kind", "operation", "result"})
+
+	childResourceDrift = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "child_resource",
+		Name:      "drift_total",
+		Help:      "Number of out-of-band changes detected on Capp child resources, by kind and whether they were corrected.",
+	}, []string{"kind", "action"})
+
+	webhookDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "denials_total",
+		Help:      "Number of Capp admission requests denied by the validating webhook, by rule.",
+	}, []string{"rule"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		resourceManagerSyncDuration,
+		resourceManagerSyncErrors,
+		childResourceOperations,
+		childResourceDrift,
+		webhookDenials,
+	)
+}
+
+// ObserveResourceManagerSync records the duration and outcome of a resource manager sync.
+func ObserveResourceManagerSync(manager string, duration time.Duration, err error) {
+	resourceManagerSyncDuration.WithLabelValues(manager).Observe(duration.Seconds())
+	if err != nil {
+		resourceManagerSyncErrors.WithLabelValues(manager).Inc()
+	}
+}
+
+// RecordChildResourceOperation records a create, update, apply or delete call for a child resource of the given kind.
+func RecordChildResourceOperation(kind, operation string, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	childResourceOperations.WithLabelValues(kind, operation, result).Inc()
+}
+
+// RecordDrift records an out-of-band change detected on a child resource of the given kind.
+func RecordDrift(kind, action string) {
+	childResourceDrift.WithLabelValues(kind, action).Inc()
+}
+
+// RecordWebhookDenial records a Capp admission request denied by the given rule.
+func RecordWebhookDenial(rule string) {
+	webhookDenials.WithLabelValues(rule).Inc()
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestObserveResourceManagerSync(t *testing.T) {
+	const manager = "test-manager"
+
+	ObserveResourceManagerSync(manager, time.Millisecond, nil)
+	ObserveResourceManagerSync(manager, time.Millisecond, errors.New("boom"))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(resourceManagerSyncDuration.MustCurryWith(prometheus.Labels{"manager": manager})))
+	assert.InDelta(t, 1, testutil.ToFloat64(resourceManagerSyncErrors.WithLabelValues(manager)), 0)
+}
+
+func TestRecordChildResourceOperation(t *testing.T) {
+	const kind = "TestKind"
+
+	RecordChildResourceOperation(kind, OperationCreate, nil)
+	RecordChildResourceOperation(kind, OperationCreate, nil)
+	RecordChildResourceOperation(kind, OperationDelete, errors.New("boom"))
+
+	assert.InDelta(t, 2, testutil.ToFloat64(childResourceOperations.WithLabelValues(kind, OperationCreate, resultSuccess)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(childResourceOperations.WithLabelValues(kind, OperationDelete, resultError)), 0)
+}
+
+func TestRecordDrift(t *testing.T) {
+	const kind = "TestKind"
+
+	RecordDrift(kind, DriftActionCorrected)
+	RecordDrift(kind, DriftActionPaused)
+	RecordDrift(kind, DriftActionPaused)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(childResourceDrift.WithLabelValues(kind, DriftActionCorrected)), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(childResourceDrift.WithLabelValues(kind, DriftActionPaused)), 0)
+}
+
+func TestRecordWebhookDenial(t *testing.T) {
+	const rule = "test-rule"
+
+	RecordWebhookDenial(rule)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(webhookDenials.WithLabelValues(rule)), 0)
+}
+
+func TestCappCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, cappv1alpha1.AddToScheme(scheme))
+
+	newCapp := func(name, state, metric, readyReason string) *cappv1alpha1.Capp {
+		capp := &cappv1alpha1.Capp{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: cappv1alpha1.CappSpec{
+				State:     state,
+				ScaleSpec: cappv1alpha1.ScaleSpec{Metric: metric},
+			},
+		}
+		if readyReason != "" {
+			capp.Status.Conditions = []metav1.Condition{{
+				Type:   cappv1alpha1.CappConditionReady,
+				Status: metav1.ConditionFalse,
+				Reason: readyReason,
+			}}
+		}
+		return capp
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newCapp("ready", cappv1alpha1.CappStateEnabled, "concurrency", cappv1alpha1.CappReadyReasonReady),
+			newCapp("not-ready", cappv1alpha1.CappStateEnabled, "cpu", cappv1alpha1.CappReadyReasonKnativeNotReady),
+			newCapp("disabled", cappv1alpha1.CappStateDisabled, "concurrency", ""),
+		).
+		Build()
+
+	expected := `
+# HELP capp_capps_by_ready_reason Number of Capps by the reason of their Ready condition.
+# TYPE capp_capps_by_ready_reason gauge
+capp_capps_by_ready_reason{reason="KnativeServiceNotReady"} 1
+capp_capps_by_ready_reason{reason="Ready"} 1
+capp_capps_by_ready_reason{reason="Unknown"} 1
+# HELP capp_capps_by_scale_metric Number of Capps by scale metric.
+# TYPE capp_capps_by_scale_metric gauge
+capp_capps_by_scale_metric{metric="concurrency"} 2
+capp_capps_by_scale_metric{metric="cpu"} 1
+# HELP capp_capps_by_state Number of Capps by state.
+# TYPE capp_capps_by_state gauge
+capp_capps_by_state{state="disabled"} 1
+capp_capps_by_state{state="enabled"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(NewCappCollector(fakeClient), strings.NewReader(expected)))
+}
@@ -34,10 +34,6 @@ const (
 	elasticIndex              = "my-index"
 	missingSecretName         = "missing-secret"
 	missingRequiredKeyMessage = "missing required key"
-
-	allowedHostnamePattern      = `.*\.example\.com`
-	nonMatchingHostname         = "myapp.other.com"
-	errMustMatchAllowedPatterns = "must match one of the allowed patterns"
 )
 
 func newScheme(t *testing.T) *runtime.Scheme {
@@ -59,6 +55,7 @@ func newCappConfig() *cappv1alpha1.CappConfig {
 		Spec: cappv1alpha1.CappConfigSpec{
 			AllowedHostnamePatterns: []cappv1alpha1.HostnamePattern{{Match: ".*"}},
 			MaxKafkaConsumers:       5,
+			ImagePolicy:             cappv1alpha1.ImagePolicy{ForbidLatestTag: true},
 			AutoscaleConfig: cappv1alpha1.AutoscaleConfig{
 				MinReplicasLimit: 10,
 				MaxScaleDelay:    100,
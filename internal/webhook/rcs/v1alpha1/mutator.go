@@ -9,6 +9,7 @@ import (
 	v1alpha2 "github.com/dana-team/container-app-operator/api/v1alpha1"
 	"github.com/dana-team/container-app-operator/internal/kinds/capp/cappmeta"
 	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	"github.com/dana-team/container-app-operator/internal/tracing"
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,11 +25,14 @@ type CappMutator struct {
 // +kubebuilder:webhook:path=/mutate-capp,mutating=true,sideEffects=None,failurePolicy=fail,groups=rcs.dana.io,resources=capps,verbs=create;update,versions=v1alpha1,name=capp.dana.io,admissionReviewVersions=v1;v1beta1
 
 var (
-	lastUpdatedByAnnotationKey = cappmeta.CappAPIGroup + "/last-updated-by"
+	lastUpdatedByAnnotationKey = cappmeta.LastUpdatedByAnnotationKey
 )
 
 // Handle implements the mutation webhook.
 func (c *CappMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx, span := tracing.Start(ctx, "CappMutator.Handle", tracing.CappAttributes(req.Namespace, req.Name)...)
+	defer span.End()
+
 	logger := log.FromContext(ctx).WithValues("mutation webhook", "capp mutation Webhook", "Name", req.Name)
 
 	if req.SubResource == "status" {
@@ -45,13 +49,17 @@ func (c *CappMutator) Handle(ctx context.Context, req admission.Request) admissi
 		return admission.Allowed("object is being deleted")
 	}
 
-	cappConfig, err := rmanagers.GetCappConfig(ctx, c.Client)
+	configCtx, configSpan := tracing.Start(ctx, "CappMutator.GetEffectiveCappConfig")
+	cappConfig, _, err := rmanagers.GetEffectiveCappConfig(configCtx, c.Client, req.Namespace)
+	tracing.End(configSpan, err)
 	if err != nil {
 		logger.Error(err, "failed to get RCS Config")
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
+	_, mutateSpan := tracing.Start(ctx, "CappMutator.Mutate")
 	c.handle(&capp, cappConfig, req.UserInfo.Username)
+	mutateSpan.End()
 
 	marshaledCapp, err := json.Marshal(capp)
 	if err != nil {
@@ -62,11 +70,17 @@ func (c *CappMutator) Handle(ctx context.Context, req admission.Request) admissi
 	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledCapp)
 }
 
-// handle implements the main mutating logic. It modifies the annotations and resources of
-// a Capp based on requester data and RCS Config.
+// handle implements the main mutating logic. It modifies the annotations, resources and security
+// contexts of a Capp based on requester data and RCS Config.
 func (c *CappMutator) handle(capp *v1alpha2.Capp, cappConfig *v1alpha2.CappConfig, username string) {
 	mutateAnnotations(capp, username)
+	SetDefaults(capp, cappConfig)
+}
+
+// SetDefaults applies the defaults the CappConfig sets on a Capp when it is admitted.
+func SetDefaults(capp *v1alpha2.Capp, cappConfig *v1alpha2.CappConfig) {
 	mutateResources(capp, cappConfig.Spec.DefaultResources)
+	mutateSecurityContexts(capp, cappConfig.Spec.SecurityConfig)
 }
 
 // mutateAnnotations adds a last-updated-by annotation, indicating the username who last updated the Capp.
@@ -103,3 +117,20 @@ func setResourceQuantity(resourceList *corev1.ResourceList, defaultResources cor
 		}
 	}
 }
+
+// mutateSecurityContexts sets the security context defaults of the security config on every container
+// and init container of the Capp, unless its namespace is exempt. Fields the container already sets are
+// left untouched.
+func mutateSecurityContexts(capp *v1alpha2.Capp, securityConfig v1alpha2.SecurityConfig) {
+	if rmanagers.IsSecurityExempt(capp.Namespace, securityConfig) {
+		return
+	}
+
+	podSpec := &capp.Spec.ConfigurationSpec.Template.Spec
+	for i := range podSpec.InitContainers {
+		rmanagers.SetSecurityContextDefaults(&podSpec.InitContainers[i], securityConfig)
+	}
+	for i := range podSpec.Containers {
+		rmanagers.SetSecurityContextDefaults(&podSpec.Containers[i], securityConfig)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	knativeautoscaling "knative.dev/serving/pkg/apis/autoscaling"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -303,3 +304,66 @@ func TestMutateResources(t *testing.T) {
 		require.Equal(t, resource.MustParse("512Mi"), second.Resources.Limits[corev1.ResourceMemory])
 	})
 }
+
+func TestMutateSecurityContexts(t *testing.T) {
+	securityConfig := cappv1alpha1.SecurityConfig{
+		RunAsNonRoot:           true,
+		DropAllCapabilities:    true,
+		ReadOnlyRootFilesystem: true,
+		SeccompRuntimeDefault:  true,
+		ExemptNamespaces:       []string{"exempt-ns"},
+	}
+
+	t.Run("skips mutation when no default is enabled", func(t *testing.T) {
+		capp := &cappv1alpha1.Capp{}
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: testContainerName}}
+
+		mutateSecurityContexts(capp, cappv1alpha1.SecurityConfig{})
+
+		require.Nil(t, capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].SecurityContext)
+	})
+
+	t.Run("injects defaults into containers and init containers", func(t *testing.T) {
+		capp := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Namespace: nsName}}
+		capp.Spec.ConfigurationSpec.Template.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: testContainerName}}
+
+		mutateSecurityContexts(capp, securityConfig)
+
+		want := &corev1.SecurityContext{
+			RunAsNonRoot:           ptr.To(true),
+			Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			ReadOnlyRootFilesystem: ptr.To(true),
+			SeccompProfile:         &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		require.Equal(t, want, capp.Spec.ConfigurationSpec.Template.Spec.InitContainers[0].SecurityContext)
+		require.Equal(t, want, capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].SecurityContext)
+	})
+
+	t.Run("preserves fields set by the container", func(t *testing.T) {
+		capp := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Namespace: nsName}}
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{
+			Name: testContainerName,
+			SecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(false),
+				Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"NET_RAW"}},
+			},
+		}}
+
+		mutateSecurityContexts(capp, securityConfig)
+
+		securityContext := capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].SecurityContext
+		require.False(t, *securityContext.ReadOnlyRootFilesystem)
+		require.Equal(t, []corev1.Capability{"NET_RAW"}, securityContext.Capabilities.Drop)
+		require.True(t, *securityContext.RunAsNonRoot)
+	})
+
+	t.Run("skips mutation in exempt namespaces", func(t *testing.T) {
+		capp := &cappv1alpha1.Capp{ObjectMeta: metav1.ObjectMeta{Namespace: "exempt-ns"}}
+		capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: testContainerName}}
+
+		mutateSecurityContexts(capp, securityConfig)
+
+		require.Nil(t, capp.Spec.ConfigurationSpec.Template.Spec.Containers[0].SecurityContext)
+	})
+}
@@ -7,24 +7,28 @@ import (
 	"maps"
 	"net"
 	"net/http"
-	"regexp"
+	"path"
 	"slices"
 	"strings"
 
 	"github.com/cloudevents/sdk-go/v2/event"
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	"github.com/dana-team/container-app-operator/internal/kinds/capp/policy"
 	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
+	"github.com/dana-team/container-app-operator/internal/metrics"
+	"github.com/dana-team/container-app-operator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
-	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	kafkasecurity "knative.dev/eventing-kafka-broker/control-plane/pkg/security"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
-	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
-	"knative.dev/pkg/network"
 	kautoscaling "knative.dev/serving/pkg/apis/autoscaling"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
@@ -39,6 +43,29 @@ const (
 	elasticSecretKey = "elastic"
 )
 
+// Rules reported in the webhook denial metrics.
+const (
+	ruleCappConfig          = "cappconfig"
+	ruleHostnameImmutable   = "hostname-immutable"
+	ruleRouteVisibility     = "route-visibility"
+	ruleHostnamePattern     = "hostname-pattern"
+	ruleHostnameTaken       = "hostname-taken"
+	ruleLogSecret           = "log-secret"
+	ruleRouteAuth           = "route-auth"
+	ruleNFSVolumeMounts     = "nfs-volume-mounts"
+	ruleVolumeNames         = "volume-names"
+	ruleNFSVolumeUpdate     = "nfs-volume-update"
+	rulePVCVolumeUpdate     = "pvc-volume-update"
+	ruleSecretsEnv          = "secrets-env"
+	ruleNetworkSpec         = "network-spec"
+	ruleEventSources        = "event-sources"
+	ruleTemplateAnnotations = "template-annotations"
+	ruleScaleSpec           = "scale-spec"
+	ruleScaleToZero         = "scale-to-zero"
+	ruleImagePolicy         = "image-policy"
+	ruleSecurityPolicy      = "security-policy"
+)
+
 type CappValidator struct {
 	Client  client.Client
 	Decoder admission.Decoder
@@ -48,6 +75,9 @@ type CappValidator struct {
 // +kubebuilder:webhook:path=/validate-capp,mutating=false,sideEffects=None,failurePolicy=fail,groups=rcs.dana.io,resources=capps,verbs=create;update,versions=v1alpha1,name=capp.validate.rcs.dana.io,admissionReviewVersions=v1;v1beta1
 
 func (c *CappValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ctx, span := tracing.Start(ctx, "CappValidator.Handle", tracing.CappAttributes(req.Namespace, req.Name)...)
+	defer span.End()
+
 	logger := log.FromContext(ctx).WithValues("webhook", "capp Webhook", "Name", req.Name)
 	logger.Info("Webhook request received")
 
@@ -74,57 +104,122 @@ func (c *CappValidator) Handle(ctx context.Context, req admission.Request) admis
 	return c.handle(ctx, req.Operation, capp, oldCapp)
 }
 
+// validationCheck is a single validation rule of the Capp validating webhook.
+type validationCheck struct {
+	rule  string
+	check func(ctx context.Context) error
+}
+
 func (c *CappValidator) handle(ctx context.Context, operation admissionv1.Operation, capp cappv1alpha1.Capp, oldCapp *cappv1alpha1.Capp) admission.Response {
-	config, err := rmanagers.GetCappConfig(ctx, c.Client)
+	var config *cappv1alpha1.CappConfig
+	err := runCheck(ctx, ruleCappConfig, func(ctx context.Context) error {
+		var err error
+		config, _, err = rmanagers.GetEffectiveCappConfig(ctx, c.Client, capp.Namespace)
+		return err
+	})
 	if err != nil {
-		return admission.Denied("Failed to fetch CappConfig")
-	}
-
-	var allowedHostnamePatterns []cappv1alpha1.HostnamePattern
-	if config.Spec.AllowedHostnamePatterns != nil {
-		allowedHostnamePatterns = config.Spec.AllowedHostnamePatterns
+		return deny(ruleCappConfig, "Failed to fetch CappConfig")
 	}
 
-	if err := validateHostnameImmutability(operation, capp, oldCapp); err != nil {
-		return admission.Denied(err.Error())
-	}
+	hostnameChanged := operation == admissionv1.Create || capp.Spec.RouteSpec.Hostname != oldCapp.Spec.RouteSpec.Hostname
 
-	if operation == admissionv1.Create || capp.Spec.RouteSpec.Hostname != oldCapp.Spec.RouteSpec.Hostname {
-		if errs := validateDomainName(capp.Spec.RouteSpec.Hostname, allowedHostnamePatterns); errs != nil {
-			return admission.Denied(errs.Error())
-		}
-		taken, err := isDomainNameTaken(ctx, capp.Spec.RouteSpec.Hostname)
-		if err != nil {
-			return admission.Denied(fmt.Sprintf("hostname check error: %v", err))
-		}
-		if taken {
-			return admission.Denied(fmt.Sprintf("invalid name %q: hostname must be unique and not already taken", capp.Spec.RouteSpec.Hostname))
-		}
-	}
-
-	if capp.Spec.LogSpec.PasswordSecret != "" {
-		if err := validateSecretHasKeys(ctx, c.Client, capp.Namespace, capp.Spec.LogSpec.PasswordSecret, []string{elasticSecretKey}); err != nil {
-			return admission.Denied(err.Error())
+	checks := []validationCheck{
+		{rule: ruleHostnameImmutable, check: func(context.Context) error {
+			return validateHostnameImmutability(operation, capp, oldCapp)
+		}},
+		{rule: ruleRouteVisibility, check: func(context.Context) error {
+			return validateRouteVisibility(capp)
+		}},
+		{rule: ruleHostnamePattern, check: func(context.Context) error {
+			if !hostnameChanged {
+				return nil
+			}
+			return policy.ValidateDomainName(capp.Spec.RouteSpec.Hostname, config.Spec.AllowedHostnamePatterns)
+		}},
+		{rule: ruleHostnameTaken, check: func(ctx context.Context) error {
+			if !hostnameChanged {
+				return nil
+			}
+			taken, err := isDomainNameTaken(ctx, capp.Spec.RouteSpec.Hostname)
+			if err != nil {
+				return fmt.Errorf("hostname check error: %w", err)
+			}
+			if taken {
+				return fmt.Errorf("invalid name %q: hostname must be unique and not already taken", capp.Spec.RouteSpec.Hostname)
+			}
+			return nil
+		}},
+		{rule: ruleLogSecret, check: func(ctx context.Context) error {
+			if capp.Spec.LogSpec.PasswordSecret == "" {
+				return nil
+			}
+			return validateSecretHasKeys(ctx, c.Client, capp.Namespace, capp.Spec.LogSpec.PasswordSecret, []string{elasticSecretKey})
+		}},
+		{rule: ruleRouteAuth, check: func(ctx context.Context) error {
+			return validateRouteAuth(ctx, c.Client, capp)
+		}},
+		{rule: ruleNFSVolumeMounts, check: func(context.Context) error {
+			return validateNFSVolumeMounts(capp)
+		}},
+		{rule: ruleVolumeNames, check: func(context.Context) error {
+			return validateVolumeNames(capp)
+		}},
+		{rule: ruleNFSVolumeUpdate, check: func(context.Context) error {
+			return validateNFSVolumeUpdate(operation, capp, oldCapp)
+		}},
+		{rule: rulePVCVolumeUpdate, check: func(context.Context) error {
+			return validatePVCVolumeUpdate(operation, capp, oldCapp)
+		}},
+		{rule: ruleSecretsEnv, check: func(context.Context) error {
+			if err := validateExternalSecretsServed(c.Client.RESTMapper(), operation, capp, oldCapp); err != nil {
+				return err
+			}
+			return validateSecretsEnv(capp)
+		}},
+		{rule: ruleNetworkSpec, check: func(context.Context) error {
+			return validateNetworkSpec(capp)
+		}},
+		{rule: ruleEventSources, check: func(ctx context.Context) error {
+			return validateEventSources(ctx, c.Client, capp, config.Spec.MaxKafkaConsumers)
+		}},
+		{rule: ruleTemplateAnnotations, check: func(context.Context) error {
+			return validateTemplateAnnotations(capp)
+		}},
+		{rule: ruleScaleSpec, check: func(context.Context) error {
+			return policy.ValidateScaleSpec(capp, config.Spec.AutoscaleConfig)
+		}},
+		{rule: ruleScaleToZero, check: func(context.Context) error {
+			return policy.ValidateScaleToZero(capp, config.Spec.AutoscaleConfig)
+		}},
+		{rule: ruleImagePolicy, check: func(context.Context) error {
+			return policy.ValidateImagePolicy(capp, config.Spec.ImagePolicy)
+		}},
+		{rule: ruleSecurityPolicy, check: func(context.Context) error {
+			return policy.ValidatePrivilegeEscalation(capp, config.Spec.SecurityConfig)
+		}},
+	}
+
+	for _, vc := range checks {
+		if err := runCheck(ctx, vc.rule, vc.check); err != nil {
+			return deny(vc.rule, err.Error())
 		}
 	}
 
-	if err := validateNFSVolumeMounts(capp); err != nil {
-		return admission.Denied(err.Error())
-	}
-
-	if err := validateEventSources(ctx, c.Client, capp, config.Spec.MaxKafkaConsumers); err != nil {
-		return admission.Denied(err.Error())
-	}
-
-	if err := validateTemplateAnnotations(capp); err != nil {
-		return admission.Denied(err.Error())
-	}
+	return admission.Allowed("")
+}
 
-	if err := validateScaleSpec(capp, config.Spec.AutoscaleConfig); err != nil {
-		return admission.Denied(err.Error())
-	}
+// runCheck runs a validation check in its own span.
+func runCheck(ctx context.Context, rule string, check func(ctx context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "CappValidator.Check", attribute.String("capp.webhook.rule", rule))
+	err := check(ctx)
+	tracing.End(span, err)
+	return err
+}
 
-	return admission.Allowed("")
+// deny records the denial in the webhook metrics and returns a denied admission response.
+func deny(rule, message string) admission.Response {
+	metrics.RecordWebhookDenial(rule)
+	return admission.Denied(message)
 }
 
 func validateHostnameImmutability(operation admissionv1.Operation, capp cappv1alpha1.Capp, oldCapp *cappv1alpha1.Capp) error {
@@ -141,15 +236,84 @@ func validateHostnameImmutability(operation admissionv1.Operation, capp cappv1al
 	return fmt.Errorf("spec.routeSpec.hostname is immutable once set")
 }
 
+// validateRouteVisibility makes sure a cluster-local Capp has no hostname or TLS, since it is not
+// reachable from outside the cluster.
+func validateRouteVisibility(capp cappv1alpha1.Capp) error {
+	routeSpec := capp.Spec.RouteSpec
+	if routeSpec.Visibility != cappv1alpha1.RouteVisibilityClusterLocal {
+		return nil
+	}
+	if routeSpec.Hostname != "" {
+		return fmt.Errorf("spec.routeSpec.hostname: a cluster-local Capp cannot have a hostname")
+	}
+	if routeSpec.TlsEnabled {
+		return fmt.Errorf("spec.routeSpec.tlsEnabled: a cluster-local Capp cannot enable TLS")
+	}
+	if routeSpec.Auth != nil {
+		return fmt.Errorf("spec.routeSpec.auth: a cluster-local Capp cannot require a login")
+	}
+	return nil
+}
+
+// validateRouteAuth makes sure the client Secret of the login of the Capp holds the keys the
+// oauth2-proxy sidecar reads, and that no container of the Capp takes the name of the sidecar.
+func validateRouteAuth(ctx context.Context, r client.Reader, capp cappv1alpha1.Capp) error {
+	auth := capp.Spec.RouteSpec.Auth
+	if auth == nil {
+		return nil
+	}
+	for _, container := range capp.Spec.ConfigurationSpec.Template.Spec.Containers {
+		if container.Name == rmanagers.AuthProxyContainerName {
+			return fmt.Errorf("spec.routeSpec.auth: container name %q is reserved for the login sidecar", container.Name)
+		}
+		// The login sidecar receives the requests, so the containers become sidecars Knative does not probe.
+		if container.ReadinessProbe != nil || container.LivenessProbe != nil || container.StartupProbe != nil {
+			return fmt.Errorf("spec.routeSpec.auth: container %q cannot declare probes when the Capp requires a login", container.Name)
+		}
+	}
+	return validateSecretHasKeys(ctx, r, capp.Namespace, auth.ClientSecret, rmanagers.AuthSecretKeys)
+}
+
+// validateNFSVolumeMounts makes sure every NFS volume is mounted by at least one container, either by
+// the volumeMounts of the container or by the mounts declared on the volume, that the declared mounts
+// refer to existing containers and that no two volumes are mounted at the same path of a container.
 func validateNFSVolumeMounts(capp cappv1alpha1.Capp) error {
 	if len(capp.Spec.VolumesSpec.NFSVolumes) == 0 {
 		return nil
 	}
 
+	containers := capp.Spec.ConfigurationSpec.Template.Spec.Containers
 	mountedVolumes := make(map[string]struct{})
-	for _, container := range capp.Spec.ConfigurationSpec.Template.Spec.Containers {
+	mountPaths := make([]map[string]string, len(containers))
+	for i, container := range containers {
+		mountPaths[i] = make(map[string]string)
 		for _, volumeMount := range container.VolumeMounts {
 			mountedVolumes[volumeMount.Name] = struct{}{}
+			if err := addMountPath(mountPaths[i], container.Name, volumeMount.MountPath, volumeMount.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, nfsVolume := range capp.Spec.VolumesSpec.NFSVolumes {
+		for _, mount := range nfsVolume.Mounts {
+			if path.IsAbs(mount.SubPath) || slices.Contains(strings.Split(mount.SubPath, "/"), "..") {
+				return fmt.Errorf("invalid nfsVolumes: subPath %q of volume %q must be a relative path within the volume", mount.SubPath, nfsVolume.Name)
+			}
+			for _, name := range mount.Containers {
+				if !slices.ContainsFunc(containers, func(container corev1.Container) bool { return container.Name == name }) {
+					return fmt.Errorf("invalid nfsVolumes: volume %q is mounted in unknown container %q", nfsVolume.Name, name)
+				}
+			}
+			for i, container := range containers {
+				if len(mount.Containers) > 0 && !slices.Contains(mount.Containers, container.Name) {
+					continue
+				}
+				mountedVolumes[nfsVolume.Name] = struct{}{}
+				if err := addMountPath(mountPaths[i], container.Name, mount.MountPath, nfsVolume.Name); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -171,6 +335,209 @@ func validateNFSVolumeMounts(capp cappv1alpha1.Capp) error {
 	return fmt.Errorf("invalid nfsVolumes: volumes [%s] must be mounted by at least one container", strings.Join(missingVolumeNames, ", "))
 }
 
+// addMountPath records that volume is mounted at mountPath of container in mountPaths, and fails if
+// a volume, even the same one, is already mounted there.
+func addMountPath(mountPaths map[string]string, container, mountPath, volume string) error {
+	mountPath = path.Clean(mountPath)
+	if other, ok := mountPaths[mountPath]; ok {
+		if other == volume {
+			return fmt.Errorf("invalid nfsVolumes: volume %q is mounted twice at %q in container %q", volume, mountPath, container)
+		}
+		return fmt.Errorf("invalid nfsVolumes: volumes %q and %q are both mounted at %q in container %q", other, volume, mountPath, container)
+	}
+	mountPaths[mountPath] = volume
+	return nil
+}
+
+// validateVolumeNames makes sure the names of the volumes of the Capp are unique across all kinds
+// of volumes, since they share the volumes of the Knative revision template.
+func validateVolumeNames(capp cappv1alpha1.Capp) error {
+	volumes := capp.Spec.VolumesSpec
+	seen := make(map[string]string)
+	check := func(path, name string) error {
+		if other, dup := seen[name]; dup {
+			return fmt.Errorf("%s: duplicate volume name %q, also used in %s", path, name, other)
+		}
+		seen[name] = path
+		return nil
+	}
+
+	for i, volume := range volumes.NFSVolumes {
+		if err := check(fmt.Sprintf("spec.volumesSpec.nfsVolumes[%d]", i), volume.Name); err != nil {
+			return err
+		}
+	}
+	for i, volume := range volumes.PVCVolumes {
+		if err := check(fmt.Sprintf("spec.volumesSpec.pvcVolumes[%d]", i), volume.Name); err != nil {
+			return err
+		}
+	}
+	for i, volume := range volumes.ConfigMapVolumes {
+		if err := check(fmt.Sprintf("spec.volumesSpec.configMapVolumes[%d]", i), volume.Name); err != nil {
+			return err
+		}
+	}
+	for i, volume := range volumes.SecretVolumes {
+		if err := check(fmt.Sprintf("spec.volumesSpec.secretVolumes[%d]", i), volume.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateExternalSecretsServed makes sure the ExternalSecret kind of the External Secrets Operator is
+// served when a Capp declares external secrets, since they would otherwise never be synced. Capps whose
+// secrets do not change are let through, so that they can still be updated and deleted.
+func validateExternalSecretsServed(mapper meta.RESTMapper, operation admissionv1.Operation, capp cappv1alpha1.Capp, oldCapp *cappv1alpha1.Capp) error {
+	if len(capp.Spec.SecretsSpec.Secrets) == 0 {
+		return nil
+	}
+	if operation == admissionv1.Update && equality.Semantic.DeepEqual(capp.Spec.SecretsSpec, oldCapp.Spec.SecretsSpec) {
+		return nil
+	}
+
+	gvk := rmanagers.ExternalSecretGVK
+	if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("spec.secretsSpec: the External Secrets Operator is not installed, %s %s is not served", gvk.Kind, gvk.GroupVersion())
+		}
+		return fmt.Errorf("failed to look up %s: %w", gvk.Kind, err)
+	}
+	return nil
+}
+
+// validateSecretsEnv makes sure the environment variables set from external secrets refer to existing
+// containers and are not already set in them, either by the container or by another external secret.
+func validateSecretsEnv(capp cappv1alpha1.Capp) error {
+	containers := capp.Spec.ConfigurationSpec.Template.Spec.Containers
+	envVars := make([]map[string]string, len(containers))
+	for i, container := range containers {
+		envVars[i] = make(map[string]string, len(container.Env))
+		for _, env := range container.Env {
+			envVars[i][env.Name] = fmt.Sprintf("container %q", container.Name)
+		}
+	}
+
+	for i, secret := range capp.Spec.SecretsSpec.Secrets {
+		for j, env := range secret.Env {
+			path := fmt.Sprintf("spec.secretsSpec.secrets[%d].env[%d]", i, j)
+			for _, name := range env.Containers {
+				if !slices.ContainsFunc(containers, func(container corev1.Container) bool { return container.Name == name }) {
+					return fmt.Errorf("%s: unknown container %q", path, name)
+				}
+			}
+			for k, container := range containers {
+				if len(env.Containers) > 0 && !slices.Contains(env.Containers, container.Name) {
+					continue
+				}
+				if other, ok := envVars[k][env.Name]; ok {
+					return fmt.Errorf("%s: environment variable %q of container %q is already set by %s", path, env.Name, container.Name, other)
+				}
+				envVars[k][env.Name] = fmt.Sprintf("secret %q", secret.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNetworkSpec makes sure the peers of the network spec are valid namespace names, Capp names
+// and CIDRs, so that the NetworkPolicy generated from them is accepted.
+func validateNetworkSpec(capp cappv1alpha1.Capp) error {
+	checkNames := func(path, namespace, cappName string) error {
+		if namespace != "" {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				return fmt.Errorf("%s.namespace: invalid namespace %q: %s", path, namespace, strings.Join(errs, ", "))
+			}
+		}
+		if cappName != "" {
+			if errs := validation.IsDNS1123Label(cappName); len(errs) > 0 {
+				return fmt.Errorf("%s.cappName: invalid Capp name %q: %s", path, cappName, strings.Join(errs, ", "))
+			}
+		}
+		return nil
+	}
+
+	for i, peer := range capp.Spec.NetworkSpec.Ingress {
+		if err := checkNames(fmt.Sprintf("spec.networkSpec.ingress[%d]", i), peer.Namespace, peer.CappName); err != nil {
+			return err
+		}
+	}
+	for i, peer := range capp.Spec.NetworkSpec.Egress {
+		path := fmt.Sprintf("spec.networkSpec.egress[%d]", i)
+		if err := checkNames(path, peer.Namespace, peer.CappName); err != nil {
+			return err
+		}
+		if peer.CIDR != "" {
+			if _, _, err := net.ParseCIDR(peer.CIDR); err != nil {
+				return fmt.Errorf("%s.cidr: invalid CIDR %q", path, peer.CIDR)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNFSVolumeUpdate makes sure an update never shrinks the capacity of an NFS volume, since
+// volumes can only be resized in place to a larger capacity, and never changes its access mode, which
+// is immutable on the NfsPvc.
+func validateNFSVolumeUpdate(operation admissionv1.Operation, capp cappv1alpha1.Capp, oldCapp *cappv1alpha1.Capp) error {
+	if operation != admissionv1.Update {
+		return nil
+	}
+
+	oldVolumes := make(map[string]cappv1alpha1.NFSVolume, len(oldCapp.Spec.VolumesSpec.NFSVolumes))
+	for _, volume := range oldCapp.Spec.VolumesSpec.NFSVolumes {
+		oldVolumes[volume.Name] = volume
+	}
+
+	for i, volume := range capp.Spec.VolumesSpec.NFSVolumes {
+		oldVolume, ok := oldVolumes[volume.Name]
+		if !ok {
+			continue
+		}
+		capacity, oldCapacity := volume.Capacity[corev1.ResourceStorage], oldVolume.Capacity[corev1.ResourceStorage]
+		if capacity.Cmp(oldCapacity) < 0 {
+			return fmt.Errorf("spec.volumesSpec.nfsVolumes[%d].capacity: cannot shrink volume %q from %s to %s",
+				i, volume.Name, oldCapacity.String(), capacity.String())
+		}
+		if rmanagers.NFSVolumeAccessMode(volume) != rmanagers.NFSVolumeAccessMode(oldVolume) {
+			return fmt.Errorf("spec.volumesSpec.nfsVolumes[%d].accessMode: access mode of volume %q is immutable", i, volume.Name)
+		}
+	}
+	return nil
+}
+
+// validatePVCVolumeUpdate makes sure an update of a PVC volume whose claim is created by the operator
+// never shrinks its size or changes its storage class or access mode, since the spec of a claim is
+// immutable apart from growing its storage request.
+func validatePVCVolumeUpdate(operation admissionv1.Operation, capp cappv1alpha1.Capp, oldCapp *cappv1alpha1.Capp) error {
+	if operation != admissionv1.Update {
+		return nil
+	}
+
+	oldVolumes := make(map[string]cappv1alpha1.PVCVolume, len(oldCapp.Spec.VolumesSpec.PVCVolumes))
+	for _, volume := range oldCapp.Spec.VolumesSpec.PVCVolumes {
+		oldVolumes[volume.Name] = volume
+	}
+
+	for i, volume := range capp.Spec.VolumesSpec.PVCVolumes {
+		oldVolume, ok := oldVolumes[volume.Name]
+		if !ok || volume.Size == nil || oldVolume.Size == nil {
+			continue
+		}
+		if volume.Size.Cmp(*oldVolume.Size) < 0 {
+			return fmt.Errorf("spec.volumesSpec.pvcVolumes[%d].size: cannot shrink volume %q from %s to %s",
+				i, volume.Name, oldVolume.Size.String(), volume.Size.String())
+		}
+		if ptr.Deref(volume.StorageClassName, "") != ptr.Deref(oldVolume.StorageClassName, "") {
+			return fmt.Errorf("spec.volumesSpec.pvcVolumes[%d].storageClassName: storage class of volume %q is immutable", i, volume.Name)
+		}
+		if rmanagers.PVCVolumeAccessMode(volume) != rmanagers.PVCVolumeAccessMode(oldVolume) {
+			return fmt.Errorf("spec.volumesSpec.pvcVolumes[%d].accessMode: access mode of volume %q is immutable", i, volume.Name)
+		}
+	}
+	return nil
+}
+
 func validateSecretHasKeys(ctx context.Context, r client.Reader, namespace, name string, requiredKeys []string) error {
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{Namespace: namespace, Name: name}
@@ -212,7 +579,7 @@ func validateEventSources(ctx context.Context, r client.Reader, capp cappv1alpha
 			if err := validateSecretHasKeys(ctx, r, capp.Namespace, src.KafkaSourceConfiguration.SecretRef.Name, requiredKeys); err != nil {
 				return fmt.Errorf("%s[%d]: %w", eventSourcePath, i, err)
 			}
-			if err := validateKafkaSourceConsumers(src.KafkaSourceConfiguration, maxKafkaConsumers); err != nil {
+			if err := policy.ValidateKafkaSourceConsumers(src.KafkaSourceConfiguration, maxKafkaConsumers); err != nil {
 				return fmt.Errorf("%s[%d]: %w", eventSourcePath, i, err)
 			}
 		}
@@ -230,45 +597,6 @@ func validateTemplateAnnotations(capp cappv1alpha1.Capp) error {
 	return nil
 }
 
-func validateScaleSpec(capp cappv1alpha1.Capp, autoscaleConfig cappv1alpha1.AutoscaleConfig) error {
-	minReplicas := capp.Spec.ScaleSpec.MinReplicas
-	maxReplicas := capp.Spec.ScaleSpec.MaxReplicas
-	scaleDelay := capp.Spec.ScaleSpec.ScaleDelaySeconds
-
-	if minReplicas != nil && int(*minReplicas) > autoscaleConfig.MinReplicasLimit {
-		return fmt.Errorf("invalid minReplicas %d: must be less than or equal to global min scale %d", *minReplicas, autoscaleConfig.MinReplicasLimit)
-	}
-
-	if maxReplicas != nil && int(*maxReplicas) > autoscaleConfig.MaxReplicasLimit {
-		return fmt.Errorf("invalid maxReplicas %d: must be less than or equal to global max scale %d", *maxReplicas, autoscaleConfig.MaxReplicasLimit)
-	}
-
-	if maxReplicas != nil && minReplicas != nil && *maxReplicas < *minReplicas {
-		return fmt.Errorf("invalid maxReplicas %d: must be greater than or equal to minReplicas %d", *maxReplicas, *minReplicas)
-	}
-
-	if maxReplicas != nil && minReplicas == nil && int(*maxReplicas) < autoscaleConfig.ActivationScale {
-		return fmt.Errorf("invalid maxReplicas %d: must be greater than or equal to activationScale %d",
-			*maxReplicas, autoscaleConfig.ActivationScale)
-	}
-
-	if scaleDelay != nil && int(*scaleDelay) > autoscaleConfig.MaxScaleDelay {
-		return fmt.Errorf("invalid scaleDelaySeconds %d: must be less than or equal to global max scale delay %d", *scaleDelay, autoscaleConfig.MaxScaleDelay)
-	}
-
-	return nil
-}
-
-func validateKafkaSourceConsumers(cfg *cappv1alpha1.KafkaSourceConfiguration, maxKafkaConsumers int32) error {
-	if cfg.Consumers == nil {
-		return nil
-	}
-	if *cfg.Consumers > maxKafkaConsumers {
-		return fmt.Errorf("invalid consumers %d: must be less than or equal to global max kafka consumers %d", *cfg.Consumers, maxKafkaConsumers)
-	}
-	return nil
-}
-
 // validatePingSourceConfiguration makes sure a pingSource has a valid cron schedule and that the data field (if specified) is valid JSON.
 func validatePingSourceConfiguration(ctx context.Context, cfg *cappv1alpha1.PingSourceConfiguration) error {
 	schedule := cfg.Schedule
@@ -296,52 +624,11 @@ func validatePingSourceConfiguration(ctx context.Context, cfg *cappv1alpha1.Ping
 	return nil
 }
 
-// validateDomainName checks if the hostname is valid domain name and not part of the cluster's domain.
-func validateDomainName(domainName string, allowedPatterns []cappv1alpha1.HostnamePattern) (errs *apis.FieldError) {
-	if domainName == "" {
-		return nil
-	}
-	err := validation.IsFullyQualifiedDomainName(field.NewPath("name"), domainName)
-	if err != nil {
-		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf(
-			"invalid name %q: %s", domainName, err.ToAggregate()), "name"))
-	}
-	matched := false
-	descriptions := make([]string, 0, len(allowedPatterns))
-	for i, hp := range allowedPatterns {
-		re, err := regexp.Compile(hp.Match)
-		if err != nil {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid pattern %q: %s", hp.Match, err), fmt.Sprintf("allowedHostnamePatterns[%d].pattern", i)))
-			continue
-		}
-		if hp.Explanation != "" {
-			descriptions = append(descriptions, hp.Explanation)
-		} else {
-			descriptions = append(descriptions, hp.Match)
-		}
-		if !matched && re.MatchString(domainName) {
-			matched = true
-			break
-		}
-	}
-	if !matched {
-		msg := fmt.Sprintf("invalid name %q: must match one of the allowed patterns", domainName)
-		if len(descriptions) > 0 {
-			msg = fmt.Sprintf("%s (%s)", msg, strings.Join(descriptions, ", "))
-		}
-		errs = errs.Also(apis.ErrGeneric(msg, "name").ViaField("routeSpec").ViaField("hostname"))
-	}
-
-	clusterLocalDomain := network.GetClusterDomainName()
-	if strings.HasSuffix(domainName, "."+clusterLocalDomain) {
-		errs = errs.Also(apis.ErrGeneric(
-			fmt.Sprintf("invalid name %q: must not be a subdomain of cluster local domain %q", domainName, clusterLocalDomain), "name"))
-	}
-	return errs
-}
+func isDomainNameTaken(ctx context.Context, domainName string) (taken bool, err error) {
+	ctx, span := tracing.Start(ctx, "DNS.LookupHost", attribute.String("dns.hostname", domainName))
+	defer func() { tracing.End(span, err) }()
 
-func isDomainNameTaken(ctx context.Context, domainName string) (bool, error) {
-	_, err := net.DefaultResolver.LookupHost(ctx, domainName)
+	_, err = net.DefaultResolver.LookupHost(ctx, domainName)
 	if err != nil {
 		var dnsErr *net.DNSError
 		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
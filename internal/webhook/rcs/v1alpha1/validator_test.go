@@ -6,10 +6,17 @@ import (
 	"testing"
 
 	cappv1alpha1 "github.com/dana-team/container-app-operator/api/v1alpha1"
+	rmanagers "github.com/dana-team/container-app-operator/internal/kinds/capp/resourcemanagers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -132,6 +139,17 @@ func TestCappValidatorHandle(t *testing.T) {
 			expectAllow: false,
 			expectMsg:   "secret \"" + missingSecretName + "\" not found",
 		},
+		{
+			name:      "denies capp with an image using the latest tag",
+			operation: admissionv1.Create,
+			capp: func() *cappv1alpha1.Capp {
+				capp := newCapp("")
+				capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: "app", Image: "nginx:latest"}}
+				return capp
+			}(),
+			expectAllow: false,
+			expectMsg:   "spec.configurationSpec.template.spec.containers[0].image",
+		},
 	}
 
 	for _, tc := range tests {
@@ -169,6 +187,41 @@ func TestCappValidatorHandle(t *testing.T) {
 
 }
 
+func TestCappValidatorHandleSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	scheme := newScheme(t)
+	validator := newCappValidator(t, scheme, admission.NewDecoder(scheme))
+
+	resp := validator.handle(context.Background(), admissionv1.Create, *newCapp("valid-hostname.com"), nil)
+	require.True(t, resp.Allowed)
+
+	var rules []string
+	var dnsLookups int
+	for _, span := range exporter.GetSpans() {
+		switch span.Name {
+		case "CappValidator.Check":
+			for _, attr := range span.Attributes {
+				if attr.Key == "capp.webhook.rule" {
+					rules = append(rules, attr.Value.AsString())
+				}
+			}
+		case "DNS.LookupHost":
+			dnsLookups++
+		}
+	}
+
+	assert.Equal(t, []string{
+		ruleCappConfig, ruleHostnameImmutable, ruleRouteVisibility, ruleHostnamePattern, ruleHostnameTaken, ruleLogSecret,
+		ruleRouteAuth, ruleNFSVolumeMounts, ruleVolumeNames, ruleNFSVolumeUpdate, rulePVCVolumeUpdate, ruleSecretsEnv,
+		ruleNetworkSpec, ruleEventSources, ruleTemplateAnnotations, ruleScaleSpec, ruleScaleToZero, ruleImagePolicy,
+		ruleSecurityPolicy,
+	}, rules)
+	assert.Equal(t, 1, dnsLookups)
+}
+
 func TestValidateSecretHasKeys(t *testing.T) {
 	const (
 		secretName     = "existing-secret"
@@ -307,6 +360,127 @@ func TestValidateHostnameImmutability(t *testing.T) {
 	}
 }
 
+func TestValidateRouteVisibility(t *testing.T) {
+	tests := []struct {
+		name            string
+		routeSpec       cappv1alpha1.RouteSpec
+		wantErrContains string
+	}{
+		{
+			name:      "allows an external capp with a hostname",
+			routeSpec: cappv1alpha1.RouteSpec{Hostname: newHostname, TlsEnabled: true},
+		},
+		{
+			name:      "allows a cluster-local capp without a hostname",
+			routeSpec: cappv1alpha1.RouteSpec{Visibility: cappv1alpha1.RouteVisibilityClusterLocal},
+		},
+		{
+			name:            "rejects a cluster-local capp with a hostname",
+			routeSpec:       cappv1alpha1.RouteSpec{Visibility: cappv1alpha1.RouteVisibilityClusterLocal, Hostname: newHostname},
+			wantErrContains: "spec.routeSpec.hostname",
+		},
+		{
+			name:            "rejects a cluster-local capp with tls",
+			routeSpec:       cappv1alpha1.RouteSpec{Visibility: cappv1alpha1.RouteVisibilityClusterLocal, TlsEnabled: true},
+			wantErrContains: "spec.routeSpec.tlsEnabled",
+		},
+		{
+			name: "rejects a cluster-local capp requiring a login",
+			routeSpec: cappv1alpha1.RouteSpec{
+				Visibility: cappv1alpha1.RouteVisibilityClusterLocal,
+				Auth:       &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: "oidc-client"},
+			},
+			wantErrContains: "spec.routeSpec.auth",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{RouteSpec: tc.routeSpec}}
+
+			err := validateRouteVisibility(capp)
+			if tc.wantErrContains == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.wantErrContains)
+		})
+	}
+}
+
+func TestValidateRouteAuth(t *testing.T) {
+	const clientSecretName = "oidc-client"
+
+	ctx := context.Background()
+	clientSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: clientSecretName, Namespace: nsName},
+		Data: map[string][]byte{
+			rmanagers.AuthClientIDKey:     []byte("capp"),
+			rmanagers.AuthClientSecretKey: []byte("s3cr3t"),
+			rmanagers.AuthCookieSecretKey: []byte("0123456789abcdef"),
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(clientSecret).Build()
+
+	tests := []struct {
+		name            string
+		clientSecret    string
+		containerName   string
+		probe           *corev1.Probe
+		wantErrContains string
+	}{
+		{
+			name:          "allows a login configured with a complete client secret",
+			clientSecret:  clientSecretName,
+			containerName: "app",
+		},
+		{
+			name:            "rejects a missing client secret",
+			clientSecret:    missingSecretName,
+			containerName:   "app",
+			wantErrContains: "not found",
+		},
+		{
+			name:            "rejects a container named after the login sidecar",
+			clientSecret:    clientSecretName,
+			containerName:   rmanagers.AuthProxyContainerName,
+			wantErrContains: "reserved",
+		},
+		{
+			name:            "rejects a container declaring probes",
+			clientSecret:    clientSecretName,
+			containerName:   "app",
+			probe:           &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"}}},
+			wantErrContains: "cannot declare probes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{
+				ObjectMeta: metav1.ObjectMeta{Namespace: nsName},
+				Spec: cappv1alpha1.CappSpec{
+					RouteSpec: cappv1alpha1.RouteSpec{
+						Auth: &cappv1alpha1.RouteAuth{IssuerURL: "https://issuer.example.com", ClientSecret: tc.clientSecret},
+					},
+				},
+			}
+			capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{{Name: tc.containerName, LivenessProbe: tc.probe}}
+
+			err := validateRouteAuth(ctx, fakeClient, capp)
+			if tc.wantErrContains == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.wantErrContains)
+		})
+	}
+}
+
 func TestValidateNFSVolumeMounts(t *testing.T) {
 	invalidNFSVolumesMsg := "invalid nfsVolumes"
 	mustBeMountedMsg := "must be mounted by at least one container"
@@ -377,6 +551,65 @@ func TestValidateNFSVolumeMounts(t *testing.T) {
 				mustBeMountedMsg,
 			},
 		},
+		{
+			name: "allows a volume mounted by its declared mounts",
+			nfsVolumes: []cappv1alpha1.NFSVolume{
+				{Name: nfsVolumeName, Mounts: []cappv1alpha1.NFSVolumeMount{
+					{MountPath: "/mnt/shared-data", SubPath: "reports", Containers: []string{"main"}},
+				}},
+			},
+			containers: []corev1.Container{{Name: "main"}},
+		},
+		{
+			name: "rejects a mount in an unknown container",
+			nfsVolumes: []cappv1alpha1.NFSVolume{
+				{Name: nfsVolumeName, Mounts: []cappv1alpha1.NFSVolumeMount{
+					{MountPath: "/mnt/shared-data", Containers: []string{"sidecar"}},
+				}},
+			},
+			containers:      []corev1.Container{{Name: "main"}},
+			wantErrContains: []string{invalidNFSVolumesMsg, `unknown container "sidecar"`},
+		},
+		{
+			name: "rejects a subPath escaping the volume",
+			nfsVolumes: []cappv1alpha1.NFSVolume{
+				{Name: nfsVolumeName, Mounts: []cappv1alpha1.NFSVolumeMount{
+					{MountPath: "/mnt/shared-data", SubPath: "../other"},
+				}},
+			},
+			containers:      []corev1.Container{{Name: "main"}},
+			wantErrContains: []string{invalidNFSVolumesMsg, "must be a relative path within the volume"},
+		},
+		{
+			name: "rejects volumes mounted at the same path",
+			nfsVolumes: []cappv1alpha1.NFSVolume{
+				{Name: nfsVolumeName, Mounts: []cappv1alpha1.NFSVolumeMount{{MountPath: "/mnt/data/"}}},
+			},
+			containers: []corev1.Container{
+				{
+					Name: "main",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "other-volume", MountPath: "/mnt/data"},
+					},
+				},
+			},
+			wantErrContains: []string{invalidNFSVolumesMsg, "other-volume", nfsVolumeName, `"/mnt/data"`},
+		},
+		{
+			name: "rejects a volume mounted twice at the same path",
+			nfsVolumes: []cappv1alpha1.NFSVolume{
+				{Name: nfsVolumeName, Mounts: []cappv1alpha1.NFSVolumeMount{{MountPath: "/mnt/data"}}},
+			},
+			containers: []corev1.Container{
+				{
+					Name: "main",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: nfsVolumeName, MountPath: "/mnt/data/"},
+					},
+				},
+			},
+			wantErrContains: []string{invalidNFSVolumesMsg, nfsVolumeName, "mounted twice", `"/mnt/data"`},
+		},
 	}
 
 	for _, tc := range tests {
@@ -405,287 +638,481 @@ func TestValidateNFSVolumeMounts(t *testing.T) {
 	}
 }
 
-func TestValidateEventSources(t *testing.T) {
-	ctx := context.Background()
+func TestValidateVolumeNames(t *testing.T) {
 	tests := []struct {
 		name            string
-		sources         []cappv1alpha1.SourceConfiguration
+		volumes         cappv1alpha1.VolumesSpec
 		wantErrContains []string
 	}{
 		{
-			name: "allows empty sources list",
-		},
-		{
-			name: "allows unique source names",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
-				{Name: "ping-b", PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
+			name: "allows unique names across kinds",
+			volumes: cappv1alpha1.VolumesSpec{
+				NFSVolumes:       []cappv1alpha1.NFSVolume{{Name: "data"}},
+				PVCVolumes:       []cappv1alpha1.PVCVolume{{Name: "cache"}},
+				ConfigMapVolumes: []cappv1alpha1.ConfigMapVolume{{Name: "config"}},
+				SecretVolumes:    []cappv1alpha1.SecretVolume{{Name: "credentials"}},
 			},
 		},
 		{
-			name: "rejects duplicate source names",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
+			name: "rejects a name used by two kinds",
+			volumes: cappv1alpha1.VolumesSpec{
+				NFSVolumes:    []cappv1alpha1.NFSVolume{{Name: "data"}},
+				SecretVolumes: []cappv1alpha1.SecretVolume{{Name: "data"}},
 			},
 			wantErrContains: []string{
-				"spec.eventSourcesSpec.sources",
-				"duplicate",
-				eventSourceName,
+				"spec.volumesSpec.secretVolumes[0]",
+				"duplicate volume name",
+				"spec.volumesSpec.nfsVolumes[0]",
 			},
 		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateVolumeNames(cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: tc.volumes}})
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expectedSubstring := range tc.wantErrContains {
+				require.Contains(t, err.Error(), expectedSubstring)
+			}
+		})
+	}
+}
+
+func TestValidateNFSVolumeUpdate(t *testing.T) {
+	nfsVolume := func(capacity string) []cappv1alpha1.NFSVolume {
+		return []cappv1alpha1.NFSVolume{{
+			Name:     "data",
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+		}}
+	}
+
+	withAccessMode := func(volumes []cappv1alpha1.NFSVolume, accessMode corev1.PersistentVolumeAccessMode) []cappv1alpha1.NFSVolume {
+		volumes[0].AccessMode = accessMode
+		return volumes
+	}
+
+	tests := []struct {
+		name       string
+		operation  admissionv1.Operation
+		oldVolumes []cappv1alpha1.NFSVolume
+		newVolumes []cappv1alpha1.NFSVolume
+		wantErr    string
+	}{
 		{
-			name: "allows source with ping configuration",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "* * * * * *"}},
-			},
+			name:       "allows create",
+			operation:  admissionv1.Create,
+			newVolumes: nfsVolume("1Gi"),
 		},
 		{
-			name: "rejects source with invalid cron schedule",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "not-a-cron"}},
-			},
-			wantErrContains: []string{"schedule"},
+			name:       "allows an equal capacity written differently",
+			operation:  admissionv1.Update,
+			oldVolumes: nfsVolume("1Gi"),
+			newVolumes: nfsVolume("1024Mi"),
 		},
 		{
-			name: "rejects source with invalid JSON data",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "* * * * *", Data: "not-json{"}},
-			},
-			wantErrContains: []string{"data"},
+			name:       "allows growing a volume",
+			operation:  admissionv1.Update,
+			oldVolumes: nfsVolume("1Gi"),
+			newVolumes: nfsVolume("5Gi"),
 		},
 		{
-			name: "allows source with valid schedule and valid JSON",
-			sources: []cappv1alpha1.SourceConfiguration{
-				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "*/5 * * * *", Data: `{"key":"value"}`}},
-			},
+			name:       "allows adding a volume",
+			operation:  admissionv1.Update,
+			newVolumes: nfsVolume("1Gi"),
+		},
+		{
+			name:       "rejects shrinking a volume",
+			operation:  admissionv1.Update,
+			oldVolumes: nfsVolume("5Gi"),
+			newVolumes: nfsVolume("1Gi"),
+			wantErr:    "cannot shrink",
+		},
+		{
+			name:       "allows setting the default access mode",
+			operation:  admissionv1.Update,
+			oldVolumes: nfsVolume("1Gi"),
+			newVolumes: withAccessMode(nfsVolume("1Gi"), corev1.ReadWriteMany),
+		},
+		{
+			name:       "rejects changing the access mode",
+			operation:  admissionv1.Update,
+			oldVolumes: nfsVolume("1Gi"),
+			newVolumes: withAccessMode(nfsVolume("1Gi"), corev1.ReadOnlyMany),
+			wantErr:    "is immutable",
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			capp := cappv1alpha1.Capp{
-				Spec: cappv1alpha1.CappSpec{
-					EventSourcesSpec: cappv1alpha1.EventSourcesSpec{
-						Sources: tc.sources,
-					},
-				},
-			}
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: cappv1alpha1.VolumesSpec{NFSVolumes: tc.newVolumes}}}
+			oldCapp := &cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: cappv1alpha1.VolumesSpec{NFSVolumes: tc.oldVolumes}}}
 
-			err := validateEventSources(ctx, fakeClient, capp, 5)
-			if len(tc.wantErrContains) == 0 {
+			err := validateNFSVolumeUpdate(tc.operation, capp, oldCapp)
+			if tc.wantErr == "" {
 				require.NoError(t, err)
 				return
 			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
 
-			require.Error(t, err)
-			for _, s := range tc.wantErrContains {
-				assert.Contains(t, err.Error(), s)
+func TestValidatePVCVolumeUpdate(t *testing.T) {
+	pvcVolume := func(size string) []cappv1alpha1.PVCVolume {
+		return []cappv1alpha1.PVCVolume{{Name: "cache", Size: ptr.To(resource.MustParse(size))}}
+	}
+
+	withStorageClass := func(volumes []cappv1alpha1.PVCVolume, storageClassName string) []cappv1alpha1.PVCVolume {
+		volumes[0].StorageClassName = ptr.To(storageClassName)
+		return volumes
+	}
+
+	withAccessMode := func(volumes []cappv1alpha1.PVCVolume, accessMode corev1.PersistentVolumeAccessMode) []cappv1alpha1.PVCVolume {
+		volumes[0].AccessMode = accessMode
+		return volumes
+	}
+
+	tests := []struct {
+		name       string
+		operation  admissionv1.Operation
+		oldVolumes []cappv1alpha1.PVCVolume
+		newVolumes []cappv1alpha1.PVCVolume
+		wantErr    string
+	}{
+		{
+			name:       "allows create",
+			operation:  admissionv1.Create,
+			newVolumes: pvcVolume("1Gi"),
+		},
+		{
+			name:       "allows growing a volume",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("1Gi"),
+			newVolumes: pvcVolume("5Gi"),
+		},
+		{
+			name:       "allows switching to an existing claim",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("1Gi"),
+			newVolumes: []cappv1alpha1.PVCVolume{{Name: "cache", ClaimName: "existing-claim"}},
+		},
+		{
+			name:       "rejects shrinking a volume",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("5Gi"),
+			newVolumes: pvcVolume("1Gi"),
+			wantErr:    "cannot shrink",
+		},
+		{
+			name:       "rejects changing the storage class",
+			operation:  admissionv1.Update,
+			oldVolumes: withStorageClass(pvcVolume("1Gi"), "standard"),
+			newVolumes: withStorageClass(pvcVolume("1Gi"), "fast"),
+			wantErr:    "storageClassName",
+		},
+		{
+			name:       "rejects setting a storage class",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("1Gi"),
+			newVolumes: withStorageClass(pvcVolume("1Gi"), "fast"),
+			wantErr:    "storageClassName",
+		},
+		{
+			name:       "allows setting the default access mode",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("1Gi"),
+			newVolumes: withAccessMode(pvcVolume("1Gi"), corev1.ReadWriteOnce),
+		},
+		{
+			name:       "rejects changing the access mode",
+			operation:  admissionv1.Update,
+			oldVolumes: pvcVolume("1Gi"),
+			newVolumes: withAccessMode(pvcVolume("1Gi"), corev1.ReadWriteMany),
+			wantErr:    "is immutable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: cappv1alpha1.VolumesSpec{PVCVolumes: tc.newVolumes}}}
+			oldCapp := &cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{VolumesSpec: cappv1alpha1.VolumesSpec{PVCVolumes: tc.oldVolumes}}}
+
+			err := validatePVCVolumeUpdate(tc.operation, capp, oldCapp)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
 			}
+			require.ErrorContains(t, err, tc.wantErr)
 		})
 	}
 }
 
-func TestValidateKafkaSourceConsumers(t *testing.T) {
+func TestValidateSecretsEnv(t *testing.T) {
+	secretEnv := func(name string, containers ...string) cappv1alpha1.ExternalSecretEnvVar {
+		return cappv1alpha1.ExternalSecretEnvVar{Name: name, Key: "apps/db", Containers: containers}
+	}
+
 	tests := []struct {
 		name            string
-		cfg             *cappv1alpha1.KafkaSourceConfiguration
-		maxConsumers    int32
+		secrets         []cappv1alpha1.ExternalSecret
 		wantErrContains []string
 	}{
 		{
-			name:         "allows consumers within capacity",
-			cfg:          &cappv1alpha1.KafkaSourceConfiguration{Consumers: ptr.To(int32(3))},
-			maxConsumers: 5,
+			name: "allows distinct variables",
+			secrets: []cappv1alpha1.ExternalSecret{
+				{Name: "db", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("DB_PASSWORD")}},
+				{Name: "api", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("API_TOKEN", "main")}},
+			},
 		},
 		{
-			name:         "rejects consumers above capacity",
-			cfg:          &cappv1alpha1.KafkaSourceConfiguration{Consumers: ptr.To(int32(6))},
-			maxConsumers: 5,
-			wantErrContains: []string{
-				"consumers",
-				"max kafka consumers",
+			name: "allows the same variable in different containers",
+			secrets: []cappv1alpha1.ExternalSecret{
+				{Name: "db", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("TOKEN", "main")}},
+				{Name: "api", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("TOKEN", "sidecar")}},
+			},
+		},
+		{
+			name: "rejects an unknown container",
+			secrets: []cappv1alpha1.ExternalSecret{
+				{Name: "db", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("DB_PASSWORD", "worker")}},
+			},
+			wantErrContains: []string{"spec.secretsSpec.secrets[0].env[0]", `unknown container "worker"`},
+		},
+		{
+			name: "rejects a variable set by two secrets",
+			secrets: []cappv1alpha1.ExternalSecret{
+				{Name: "db", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("TOKEN")}},
+				{Name: "api", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("TOKEN", "sidecar")}},
 			},
+			wantErrContains: []string{"spec.secretsSpec.secrets[1].env[0]", `"TOKEN"`, `secret "db"`},
+		},
+		{
+			name: "rejects a variable set by the container",
+			secrets: []cappv1alpha1.ExternalSecret{
+				{Name: "db", Env: []cappv1alpha1.ExternalSecretEnvVar{secretEnv("LOG_LEVEL")}},
+			},
+			wantErrContains: []string{`"LOG_LEVEL"`, `container "main"`},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateKafkaSourceConsumers(tc.cfg, tc.maxConsumers)
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{SecretsSpec: cappv1alpha1.SecretsSpec{Secrets: tc.secrets}}}
+			capp.Spec.ConfigurationSpec.Template.Spec.Containers = []corev1.Container{
+				{Name: "main", Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}},
+				{Name: "sidecar"},
+			}
+
+			err := validateSecretsEnv(capp)
 			if len(tc.wantErrContains) == 0 {
 				require.NoError(t, err)
 				return
 			}
 
 			require.Error(t, err)
-			for _, s := range tc.wantErrContains {
-				assert.Contains(t, err.Error(), s)
+			for _, expectedSubstring := range tc.wantErrContains {
+				require.Contains(t, err.Error(), expectedSubstring)
 			}
 		})
 	}
 }
 
-func TestValidateTemplateAnnotations(t *testing.T) {
-	forbiddenKey := knativeautoscaling.GroupName + "/minScale"
+func TestValidateExternalSecretsServed(t *testing.T) {
+	withSecrets := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{SecretsSpec: cappv1alpha1.SecretsSpec{
+		Secrets: []cappv1alpha1.ExternalSecret{{Name: "db"}},
+	}}}
+	withoutESO := meta.NewDefaultRESTMapper(nil)
+	withESO := meta.NewDefaultRESTMapper(nil)
+	withESO.Add(rmanagers.ExternalSecretGVK, meta.RESTScopeNamespace)
 
 	tests := []struct {
 		name            string
-		annotations     map[string]string
+		mapper          meta.RESTMapper
+		operation       admissionv1.Operation
+		capp            cappv1alpha1.Capp
+		oldCapp         *cappv1alpha1.Capp
 		wantErrContains []string
 	}{
 		{
-			name:        "allows capp with non-autoscaling annotations",
-			annotations: map[string]string{"app.example.com/foo": "bar"},
+			name:      "allows a capp without secrets",
+			mapper:    withoutESO,
+			operation: admissionv1.Create,
 		},
 		{
-			name:            "rejects capp with autoscaling annotation",
-			annotations:     map[string]string{forbiddenKey: "3"},
-			wantErrContains: []string{"forbidden annotation", forbiddenKey},
+			name:      "allows secrets when the operator is installed",
+			mapper:    withESO,
+			operation: admissionv1.Create,
+			capp:      withSecrets,
+		},
+		{
+			name:            "rejects secrets when the operator is not installed",
+			mapper:          withoutESO,
+			operation:       admissionv1.Create,
+			capp:            withSecrets,
+			wantErrContains: []string{"spec.secretsSpec", "External Secrets Operator is not installed"},
+		},
+		{
+			name:      "allows an update keeping the same secrets",
+			mapper:    withoutESO,
+			operation: admissionv1.Update,
+			capp:      withSecrets,
+			oldCapp:   withSecrets.DeepCopy(),
+		},
+		{
+			name:            "rejects an update adding secrets when the operator is not installed",
+			mapper:          withoutESO,
+			operation:       admissionv1.Update,
+			capp:            withSecrets,
+			oldCapp:         &cappv1alpha1.Capp{},
+			wantErrContains: []string{"External Secrets Operator is not installed"},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			capp := cappv1alpha1.Capp{}
-			capp.Spec.ConfigurationSpec.Template.Annotations = tc.annotations
-
-			err := validateTemplateAnnotations(capp)
+			err := validateExternalSecretsServed(tc.mapper, tc.operation, tc.capp, tc.oldCapp)
 			if len(tc.wantErrContains) == 0 {
 				require.NoError(t, err)
 				return
 			}
 
 			require.Error(t, err)
-			for _, s := range tc.wantErrContains {
-				assert.Contains(t, err.Error(), s)
+			for _, expectedSubstring := range tc.wantErrContains {
+				require.Contains(t, err.Error(), expectedSubstring)
 			}
 		})
 	}
 }
 
-func TestValidateScaleSpec(t *testing.T) {
-	const maxReplicasErrMsg = "maxReplicas"
-
+func TestValidateNetworkSpec(t *testing.T) {
 	tests := []struct {
-		name              string
-		minReplicas       *int32
-		maxReplicas       *int32
-		scaleDelaySeconds *int32
-		autoscaleConfig   cappv1alpha1.AutoscaleConfig
-		wantErrContains   []string
+		name            string
+		networkSpec     cappv1alpha1.NetworkSpec
+		wantErrContains []string
 	}{
 		{
-			name:        "allows when minReplicas is at the limit",
-			minReplicas: ptr.To(int32(10)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
+			name: "allows valid peers",
+			networkSpec: cappv1alpha1.NetworkSpec{
+				Ingress: []cappv1alpha1.NetworkIngressPeer{{Namespace: "frontend"}, {CappName: "gateway"}},
+				Egress:  []cappv1alpha1.NetworkEgressPeer{{CIDR: "10.0.0.0/16"}, {CappName: "db", Namespace: "data"}},
 			},
 		},
 		{
-			name:              "allows when scaleDelaySeconds is at the limit",
-			scaleDelaySeconds: ptr.To(int32(100)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
+			name: "rejects an invalid namespace",
+			networkSpec: cappv1alpha1.NetworkSpec{
+				Ingress: []cappv1alpha1.NetworkIngressPeer{{Namespace: "Frontend"}},
 			},
+			wantErrContains: []string{"spec.networkSpec.ingress[0].namespace", `"Frontend"`},
 		},
 		{
-			name:        "rejects when minReplicas exceeds the limit",
-			minReplicas: ptr.To(int32(11)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
+			name: "rejects an invalid Capp name",
+			networkSpec: cappv1alpha1.NetworkSpec{
+				Egress: []cappv1alpha1.NetworkEgressPeer{{CappName: "db_primary"}},
 			},
-			wantErrContains: []string{"minReplicas"},
+			wantErrContains: []string{"spec.networkSpec.egress[0].cappName", `"db_primary"`},
 		},
 		{
-			name:              "rejects when scaleDelaySeconds exceeds the limit",
-			scaleDelaySeconds: ptr.To(int32(101)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
+			name: "rejects an invalid CIDR",
+			networkSpec: cappv1alpha1.NetworkSpec{
+				Egress: []cappv1alpha1.NetworkEgressPeer{{CIDR: "10.0.0.0/16"}, {CIDR: "10.0.0.1"}},
 			},
-			wantErrContains: []string{"scaleDelaySeconds"},
+			wantErrContains: []string{"spec.networkSpec.egress[1].cidr", `invalid CIDR "10.0.0.1"`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{Spec: cappv1alpha1.CappSpec{NetworkSpec: tc.networkSpec}}
+
+			err := validateNetworkSpec(capp)
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expectedSubstring := range tc.wantErrContains {
+				require.Contains(t, err.Error(), expectedSubstring)
+			}
+		})
+	}
+}
+
+func TestValidateEventSources(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name            string
+		sources         []cappv1alpha1.SourceConfiguration
+		wantErrContains []string
+	}{
+		{
+			name: "allows empty sources list",
 		},
 		{
-			name:        "allows when maxReplicas is at the limit",
-			maxReplicas: ptr.To(int32(10)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
+			name: "allows unique source names",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
+				{Name: "ping-b", PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
 			},
 		},
 		{
-			name:        "rejects when maxReplicas exceeds the limit",
-			maxReplicas: ptr.To(int32(11)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
+			name: "rejects duplicate source names",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{}},
+			},
+			wantErrContains: []string{
+				"spec.eventSourcesSpec.sources",
+				"duplicate",
+				eventSourceName,
 			},
-			wantErrContains: []string{maxReplicasErrMsg},
 		},
 		{
-			name:        "rejects when maxReplicas is less than minReplicas",
-			minReplicas: ptr.To(int32(5)),
-			maxReplicas: ptr.To(int32(3)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
+			name: "allows source with ping configuration",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "* * * * * *"}},
 			},
-			wantErrContains: []string{maxReplicasErrMsg, "minReplicas"},
 		},
 		{
-			name:        "allows when maxReplicas equals minReplicas",
-			minReplicas: ptr.To(int32(3)),
-			maxReplicas: ptr.To(int32(3)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
+			name: "rejects source with invalid cron schedule",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "not-a-cron"}},
 			},
+			wantErrContains: []string{"schedule"},
 		},
 		{
-			name:        "rejects when maxReplicas is less than activationScale and minReplicas is zero",
-			maxReplicas: ptr.To(int32(1)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
-				ActivationScale:  2,
+			name: "rejects source with invalid JSON data",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "* * * * *", Data: "not-json{"}},
 			},
-			wantErrContains: []string{maxReplicasErrMsg, "activationScale"},
+			wantErrContains: []string{"data"},
 		},
 		{
-			name:        "allows when maxReplicas equals activationScale and minReplicas is zero",
-			maxReplicas: ptr.To(int32(2)),
-			autoscaleConfig: cappv1alpha1.AutoscaleConfig{
-				MinReplicasLimit: 10,
-				MaxScaleDelay:    100,
-				MaxReplicasLimit: 10,
-				ActivationScale:  2,
+			name: "allows source with valid schedule and valid JSON",
+			sources: []cappv1alpha1.SourceConfiguration{
+				{Name: eventSourceName, PingSourceConfiguration: &cappv1alpha1.PingSourceConfiguration{Schedule: "*/5 * * * *", Data: `{"key":"value"}`}},
 			},
 		},
 	}
 
+	fakeClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			capp := cappv1alpha1.Capp{
 				Spec: cappv1alpha1.CappSpec{
-					ScaleSpec: cappv1alpha1.ScaleSpec{
-						MinReplicas:       tc.minReplicas,
-						MaxReplicas:       tc.maxReplicas,
-						ScaleDelaySeconds: tc.scaleDelaySeconds,
+					EventSourcesSpec: cappv1alpha1.EventSourcesSpec{
+						Sources: tc.sources,
 					},
 				},
 			}
 
-			err := validateScaleSpec(capp, tc.autoscaleConfig)
+			err := validateEventSources(ctx, fakeClient, capp, 5)
 			if len(tc.wantErrContains) == 0 {
 				require.NoError(t, err)
 				return
@@ -699,97 +1126,39 @@ func TestValidateScaleSpec(t *testing.T) {
 	}
 }
 
-func TestValidateDomainName(t *testing.T) {
+func TestValidateTemplateAnnotations(t *testing.T) {
+	forbiddenKey := knativeautoscaling.GroupName + "/minScale"
+
 	tests := []struct {
 		name            string
-		domainName      string
-		allowedPatterns []cappv1alpha1.HostnamePattern
-		wantErr         bool
-		errContains     string
+		annotations     map[string]string
+		wantErrContains []string
 	}{
 		{
-			name:            "Valid domain matching specific pattern",
-			domainName:      "myapp.example.com",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
-			wantErr:         false,
-		},
-		{
-			name:            "Valid domain matching wild card",
-			domainName:      "myapp.any.com",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
-			wantErr:         false,
-		},
-		{
-			name:            "Invalid domain not matching pattern",
-			domainName:      nonMatchingHostname,
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
-			wantErr:         true,
-			errContains:     errMustMatchAllowedPatterns,
-		},
-		{
-			name:            "Empty allowed patterns (deny all)",
-			domainName:      "myapp.example.com",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{},
-			wantErr:         true,
-			errContains:     errMustMatchAllowedPatterns,
-		},
-		{
-			name:            "Multiple patterns, one match",
-			domainName:      "myapp.org",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.com`}, {Match: `.*\.org`}},
-			wantErr:         false,
-		},
-		{
-			name:            "Multiple patterns, no match",
-			domainName:      "myapp.net",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*\.com`}, {Match: `.*\.org`}},
-			wantErr:         true,
-			errContains:     errMustMatchAllowedPatterns,
-		},
-		{
-			name:            "Invalid FQDN syntax",
-			domainName:      "-invalid-start",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
-			wantErr:         true,
-		},
-		{
-			name:            "Invalid hostname with leading dots rejected as FQDN",
-			domainName:      "...aaa.a....",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
-			wantErr:         true,
-		},
-		{
-			name:            "Invalid hostname with underscore rejected as FQDN under wildcard patterns",
-			domainName:      "invalid_domain!",
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: `.*`}},
-			wantErr:         true,
-		},
-		{
-			name:            "Explanation appears in error message",
-			domainName:      nonMatchingHostname,
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern, Explanation: "subdomains of example.com only"}},
-			wantErr:         true,
-			errContains:     "subdomains of example.com only",
+			name:        "allows capp with non-autoscaling annotations",
+			annotations: map[string]string{"app.example.com/foo": "bar"},
 		},
 		{
-			name:            "Raw pattern shown when explanation absent",
-			domainName:      nonMatchingHostname,
-			allowedPatterns: []cappv1alpha1.HostnamePattern{{Match: allowedHostnamePattern}},
-			wantErr:         true,
-			errContains:     allowedHostnamePattern,
+			name:            "rejects capp with autoscaling annotation",
+			annotations:     map[string]string{forbiddenKey: "3"},
+			wantErrContains: []string{"forbidden annotation", forbiddenKey},
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			errs := validateDomainName(tt.domainName, tt.allowedPatterns)
-			if tt.wantErr {
-				assert.NotNil(t, errs)
-				if tt.errContains != "" {
-					assert.Contains(t, errs.Error(), tt.errContains)
-				}
-			} else {
-				assert.Nil(t, errs)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			capp := cappv1alpha1.Capp{}
+			capp.Spec.ConfigurationSpec.Template.Annotations = tc.annotations
+
+			err := validateTemplateAnnotations(capp)
+			if len(tc.wantErrContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, s := range tc.wantErrContains {
+				assert.Contains(t, err.Error(), s)
 			}
 		})
 	}